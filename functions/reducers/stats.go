@@ -0,0 +1,193 @@
+package reducers
+
+import (
+	xConstraints "go-exp/constraints"
+	"golang.org/x/exp/constraints"
+	"math"
+)
+
+// Count is a reducer that counts the values it is applied to, starting from zero
+func Count[T any](acc int, _ T) int {
+	return acc + 1
+}
+
+// Sum is a compensated sum using Neumaier's variant of Kahan summation. The zero value is an empty sum that AddSum can
+// be used with in iterator.Reduce or channels.Reduce.
+type Sum struct {
+	sum          float64
+	compensation float64
+}
+
+func (s Sum) Value() float64 {
+	return s.sum + s.compensation
+}
+
+// Reduce merges two partial sums, so that Sum satisfies async.Reducible
+func (s Sum) Reduce(other Sum) Sum {
+	s = AddSum(s, other.sum)
+	s.compensation += other.compensation
+	return s
+}
+
+// AddSum is a reducer that adds v to acc
+func AddSum[T xConstraints.Real](acc Sum, v T) Sum {
+	x := float64(v)
+	t := acc.sum + x
+	if math.Abs(acc.sum) >= math.Abs(x) {
+		acc.compensation += (acc.sum - t) + x
+	} else {
+		acc.compensation += (x - t) + acc.sum
+	}
+	acc.sum = t
+	return acc
+}
+
+// Moments tracks the count, mean, variance and skewness of a sequence using Welford's online algorithm. The zero value
+// holds no values, which are added with AddMoments.
+type Moments struct {
+	N    int
+	mean float64
+	// m2 and m3 are the sums of the squared and cubed differences from the mean
+	m2 float64
+	m3 float64
+}
+
+func (m Moments) Mean() float64 {
+	if m.N == 0 {
+		return math.NaN()
+	}
+	return m.mean
+}
+
+// Variance is the population variance
+func (m Moments) Variance() float64 {
+	if m.N == 0 {
+		return math.NaN()
+	}
+	return m.m2 / float64(m.N)
+}
+
+// SampleVariance is the variance with Bessel's correction
+func (m Moments) SampleVariance() float64 {
+	if m.N < 2 {
+		return math.NaN()
+	}
+	return m.m2 / float64(m.N-1)
+}
+
+func (m Moments) StdDev() float64 {
+	return math.Sqrt(m.Variance())
+}
+
+// Skewness is the population skewness
+func (m Moments) Skewness() float64 {
+	if m.N == 0 || m.m2 == 0 {
+		return math.NaN()
+	}
+	return math.Sqrt(float64(m.N)) * m.m3 / math.Pow(m.m2, 1.5)
+}
+
+// Reduce merges two sets of moments using the pairwise formulas from Chan et al. and Pébay, so that Moments satisfies
+// async.Reducible
+func (m Moments) Reduce(other Moments) Moments {
+	if other.N == 0 {
+		return m
+	}
+	if m.N == 0 {
+		return other
+	}
+
+	na, nb := float64(m.N), float64(other.N)
+	n := na + nb
+	delta := other.mean - m.mean
+	return Moments{
+		N:    m.N + other.N,
+		mean: m.mean + delta*nb/n,
+		m2:   m.m2 + other.m2 + delta*delta*na*nb/n,
+		m3: m.m3 + other.m3 + delta*delta*delta*na*nb*(na-nb)/(n*n) +
+			3*delta*(na*other.m2-nb*m.m2)/n,
+	}
+}
+
+// AddMoments is a reducer that adds v to the moments in acc
+func AddMoments[T xConstraints.Real](acc Moments, v T) Moments {
+	n1 := float64(acc.N)
+	acc.N++
+	n := float64(acc.N)
+	delta := float64(v) - acc.mean
+	deltaN := delta / n
+	term := delta * deltaN * n1
+	acc.mean += deltaN
+	acc.m3 += term*deltaN*(n-2) - 3*deltaN*acc.m2
+	acc.m2 += term
+	return acc
+}
+
+// MinMax tracks the minimum and maximum of a sequence in one pass. Ok is false until a value has been added with
+// AddMinMax, so the zero value can be used as the initial state.
+type MinMax[T constraints.Ordered] struct {
+	Min, Max T
+	Ok       bool
+}
+
+// Reduce merges the minimum and maximum of two partial states
+func (m MinMax[T]) Reduce(other MinMax[T]) MinMax[T] {
+	if !other.Ok {
+		return m
+	}
+	if !m.Ok {
+		return other
+	}
+	return MinMax[T]{Min(m.Min, other.Min), Max(m.Max, other.Max), true}
+}
+
+// AddMinMax is a reducer that adds v to the range tracked by acc
+func AddMinMax[T constraints.Ordered](acc MinMax[T], v T) MinMax[T] {
+	return acc.Reduce(MinMax[T]{v, v, true})
+}
+
+// ArgMin tracks the element with the smallest key, keeping the first on ties. Ok is false until an element has been
+// added with a reducer from ArgMinBy, so the zero value can be used as the initial state.
+type ArgMin[T any, K constraints.Ordered] struct {
+	Arg T
+	Key K
+	Ok  bool
+}
+
+// Reduce keeps whichever of the two states has the smaller key, preferring a on ties
+func (a ArgMin[T, K]) Reduce(other ArgMin[T, K]) ArgMin[T, K] {
+	if !a.Ok || other.Ok && other.Key < a.Key {
+		return other
+	}
+	return a
+}
+
+// ArgMinBy returns a reducer that tracks the element with the smallest key
+func ArgMinBy[T any, K constraints.Ordered](key func(T) K) func(ArgMin[T, K], T) ArgMin[T, K] {
+	return func(acc ArgMin[T, K], v T) ArgMin[T, K] {
+		return acc.Reduce(ArgMin[T, K]{v, key(v), true})
+	}
+}
+
+// ArgMax tracks the element with the largest key, keeping the first on ties. Ok is false until an element has been
+// added with a reducer from ArgMaxBy, so the zero value can be used as the initial state.
+type ArgMax[T any, K constraints.Ordered] struct {
+	Arg T
+	Key K
+	Ok  bool
+}
+
+// Reduce keeps whichever of the two states has the larger key, preferring a on ties
+func (a ArgMax[T, K]) Reduce(other ArgMax[T, K]) ArgMax[T, K] {
+	if !a.Ok || other.Ok && other.Key > a.Key {
+		return other
+	}
+	return a
+}
+
+// ArgMaxBy returns a reducer that tracks the element with the largest key
+func ArgMaxBy[T any, K constraints.Ordered](key func(T) K) func(ArgMax[T, K], T) ArgMax[T, K] {
+	return func(acc ArgMax[T, K], v T) ArgMax[T, K] {
+		return acc.Reduce(ArgMax[T, K]{v, key(v), true})
+	}
+}
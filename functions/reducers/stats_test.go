@@ -0,0 +1,86 @@
+package reducers
+
+import (
+	"go-exp/async"
+	"gotest.tools/v3/assert"
+	"math"
+	"testing"
+)
+
+var (
+	_ async.Reducible[Sum]                 = Sum{}
+	_ async.Reducible[Moments]             = Moments{}
+	_ async.Reducible[MinMax[int]]         = MinMax[int]{}
+	_ async.Reducible[ArgMin[string, int]] = ArgMin[string, int]{}
+	_ async.Reducible[ArgMax[string, int]] = ArgMax[string, int]{}
+)
+
+func fold[A, T any](s []T, fn func(A, T) A) A {
+	var acc A
+	for _, v := range s {
+		acc = fn(acc, v)
+	}
+	return acc
+}
+
+func approxEqual(a, b float64) bool {
+	return math.Abs(a-b) <= 1e-9*math.Max(1, math.Abs(b))
+}
+
+func Test_Count(t *testing.T) {
+	assert.Equal(t, fold([]string{"a", "b", "c"}, Count[string]), 3)
+}
+
+func Test_Sum(t *testing.T) {
+	s := []float64{1, 1e100, 1, -1e100}
+	assert.Equal(t, fold(s, AddSum[float64]).Value(), 2.0)
+
+	merged := fold(s[:2], AddSum[float64]).Reduce(fold(s[2:], AddSum[float64]))
+	assert.Equal(t, merged.Value(), 2.0)
+
+	tenths := make([]float64, 10)
+	for i := range tenths {
+		tenths[i] = 0.1
+	}
+	assert.Equal(t, fold(tenths, AddSum[float64]).Value(), 1.0)
+}
+
+func Test_Moments(t *testing.T) {
+	s := []int{2, 4, 4, 4, 5, 5, 7, 9}
+	m := fold(s, AddMoments[int])
+
+	assert.Equal(t, m.N, 8)
+	assert.Equal(t, m.Mean(), 5.0)
+	assert.Assert(t, approxEqual(m.Variance(), 4))
+	assert.Assert(t, approxEqual(m.StdDev(), 2))
+	assert.Assert(t, approxEqual(m.SampleVariance(), 32.0/7))
+	assert.Assert(t, approxEqual(m.Skewness(), 0.65625))
+
+	merged := fold(s[:3], AddMoments[int]).Reduce(fold(s[3:], AddMoments[int]))
+	assert.Equal(t, merged.N, m.N)
+	assert.Assert(t, approxEqual(merged.Mean(), m.Mean()))
+	assert.Assert(t, approxEqual(merged.Variance(), m.Variance()))
+	assert.Assert(t, approxEqual(merged.Skewness(), m.Skewness()))
+
+	assert.Assert(t, math.IsNaN(Moments{}.Mean()))
+}
+
+func Test_MinMax(t *testing.T) {
+	s := []int{3, -1, 7, 2}
+	m := fold(s, AddMinMax[int])
+	assert.DeepEqual(t, m, MinMax[int]{-1, 7, true})
+
+	merged := fold(s[:2], AddMinMax[int]).Reduce(fold(s[2:], AddMinMax[int])).Reduce(MinMax[int]{})
+	assert.DeepEqual(t, merged, m)
+}
+
+func Test_ArgMinMax(t *testing.T) {
+	s := []string{"bb", "a", "ccc", "d", "eee"}
+	length := func(s string) int { return len(s) }
+
+	assert.DeepEqual(t, fold(s, ArgMinBy(length)), ArgMin[string, int]{"a", 1, true})
+	assert.DeepEqual(t, fold(s, ArgMaxBy(length)), ArgMax[string, int]{"ccc", 3, true})
+
+	merged := fold(s[:2], ArgMaxBy(length)).Reduce(fold(s[2:], ArgMaxBy(length)))
+	assert.DeepEqual(t, merged, ArgMax[string, int]{"ccc", 3, true})
+}
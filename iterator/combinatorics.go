@@ -0,0 +1,212 @@
+package iterator
+
+import "slices"
+
+// Product returns an iterator over the cartesian product of its, yielding one element from each. The finite inputs are
+// read in full when iteration starts, and the products are yielded lazily in lexicographic order of the input
+// positions.
+func Product[T any](its ...func(func(T) bool)) func(func([]T) bool) {
+	return product(its, false)
+}
+
+// ProductReuse is like Product, but yields the same slice every time, overwritten in place, so a yielded slice is only
+// valid until the next iteration
+func ProductReuse[T any](its ...func(func(T) bool)) func(func([]T) bool) {
+	return product(its, true)
+}
+
+// Combinations returns an iterator over the k-length subsequences of it. it must be finite and is read in full when
+// iteration starts, and the combinations are yielded lazily in lexicographic order of the input positions.
+func Combinations[T any](it func(func(T) bool), k int) func(func([]T) bool) {
+	return combinations(it, k, false, combinationIndices)
+}
+
+// CombinationsReuse is like Combinations, but yields the same slice every time, overwritten in place, so a yielded
+// slice is only valid until the next iteration
+func CombinationsReuse[T any](it func(func(T) bool), k int) func(func([]T) bool) {
+	return combinations(it, k, true, combinationIndices)
+}
+
+// CombinationsWithReplacement returns an iterator over the k-length subsequences of it, allowing each element to be
+// repeated. it must be finite and is read in full when iteration starts, and the combinations are yielded lazily in
+// lexicographic order of the input positions.
+func CombinationsWithReplacement[T any](it func(func(T) bool), k int) func(func([]T) bool) {
+	return combinations(it, k, false, combinationWithReplacementIndices)
+}
+
+// CombinationsWithReplacementReuse is like CombinationsWithReplacement, but yields the same slice every time,
+// overwritten in place, so a yielded slice is only valid until the next iteration
+func CombinationsWithReplacementReuse[T any](it func(func(T) bool), k int) func(func([]T) bool) {
+	return combinations(it, k, true, combinationWithReplacementIndices)
+}
+
+// Permutations returns an iterator over the k-length ordered arrangements of the elements of it. it must be finite and
+// is read in full when iteration starts, and the permutations are yielded lazily in lexicographic order of the input
+// positions.
+func Permutations[T any](it func(func(T) bool), k int) func(func([]T) bool) {
+	return combinations(it, k, false, permutationIndices)
+}
+
+// PermutationsReuse is like Permutations, but yields the same slice every time, overwritten in place, so a yielded
+// slice is only valid until the next iteration
+func PermutationsReuse[T any](it func(func(T) bool), k int) func(func([]T) bool) {
+	return combinations(it, k, true, permutationIndices)
+}
+
+func product[T any](its []func(func(T) bool), reuse bool) func(func([]T) bool) {
+	return func(yield func([]T) bool) {
+		pools := make([][]T, len(its))
+		sizes := make([]int, len(its))
+		for i, it := range its {
+			pools[i] = slices.Collect(it)
+			sizes[i] = len(pools[i])
+		}
+		selectIndices(pools, productIndices(sizes), reuse, yield)
+	}
+}
+
+func combinations[T any](it func(func(T) bool), k int, reuse bool, indices func(n, k int) func(func([]int) bool)) func(func([]T) bool) {
+	if k < 0 {
+		panic("iterator: k must not be negative")
+	}
+	return func(yield func([]T) bool) {
+		pool := slices.Collect(it)
+		pools := make([][]T, k)
+		for i := range pools {
+			pools[i] = pool
+		}
+		selectIndices(pools, indices(len(pool), k), reuse, yield)
+	}
+}
+
+// selectIndices yields, for each index slice, the element at position idx[i] of pools[i] for every i
+func selectIndices[T any](pools [][]T, indices func(func([]int) bool), reuse bool, yield func([]T) bool) {
+	var out []T
+	for idx := range indices {
+		if out == nil || !reuse {
+			out = make([]T, len(idx))
+		}
+		for i, j := range idx {
+			out[i] = pools[i][j]
+		}
+		if !yield(out) {
+			return
+		}
+	}
+}
+
+// productIndices yields every combination of indices with idx[i] < sizes[i], like an odometer
+func productIndices(sizes []int) func(func([]int) bool) {
+	return func(yield func([]int) bool) {
+		for _, size := range sizes {
+			if size == 0 {
+				return
+			}
+		}
+
+		idx := make([]int, len(sizes))
+		for yield(idx) {
+			i := len(idx) - 1
+			for ; i >= 0; i-- {
+				idx[i]++
+				if idx[i] < sizes[i] {
+					break
+				}
+				idx[i] = 0
+			}
+			if i < 0 {
+				return
+			}
+		}
+	}
+}
+
+func combinationIndices(n, k int) func(func([]int) bool) {
+	return func(yield func([]int) bool) {
+		if k > n {
+			return
+		}
+
+		idx := make([]int, k)
+		for i := range idx {
+			idx[i] = i
+		}
+		for yield(idx) {
+			i := k - 1
+			for i >= 0 && idx[i] == i+n-k {
+				i--
+			}
+			if i < 0 {
+				return
+			}
+			idx[i]++
+			for j := i + 1; j < k; j++ {
+				idx[j] = idx[j-1] + 1
+			}
+		}
+	}
+}
+
+func combinationWithReplacementIndices(n, k int) func(func([]int) bool) {
+	return func(yield func([]int) bool) {
+		if n == 0 && k > 0 {
+			return
+		}
+
+		idx := make([]int, k)
+		for yield(idx) {
+			i := k - 1
+			for i >= 0 && idx[i] == n-1 {
+				i--
+			}
+			if i < 0 {
+				return
+			}
+			v := idx[i] + 1
+			for j := i; j < k; j++ {
+				idx[j] = v
+			}
+		}
+	}
+}
+
+// permutationIndices is a port of the algorithm used by Python's itertools.permutations
+func permutationIndices(n, k int) func(func([]int) bool) {
+	return func(yield func([]int) bool) {
+		if k > n {
+			return
+		}
+
+		indices := make([]int, n)
+		for i := range indices {
+			indices[i] = i
+		}
+		cycles := make([]int, k)
+		for i := range cycles {
+			cycles[i] = n - i
+		}
+
+		if !yield(indices[:k]) {
+			return
+		}
+		for {
+			i := k - 1
+			for ; i >= 0; i-- {
+				cycles[i]--
+				if cycles[i] > 0 {
+					j := n - cycles[i]
+					indices[i], indices[j] = indices[j], indices[i]
+					break
+				}
+				// rotate indices[i:] left by one
+				first := indices[i]
+				copy(indices[i:], indices[i+1:])
+				indices[n-1] = first
+				cycles[i] = n - i
+			}
+			if i < 0 || !yield(indices[:k]) {
+				return
+			}
+		}
+	}
+}
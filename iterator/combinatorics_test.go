@@ -0,0 +1,61 @@
+package iterator
+
+import (
+	"gotest.tools/v3/assert"
+	"slices"
+	"testing"
+)
+
+func TestProduct(t *testing.T) {
+	result := slices.Collect(Product(slices.Values([]int{1, 2}), slices.Values([]int{3, 4, 5})))
+	assert.DeepEqual(t, result, [][]int{{1, 3}, {1, 4}, {1, 5}, {2, 3}, {2, 4}, {2, 5}})
+
+	assert.DeepEqual(t, slices.Collect(Product[int]()), [][]int{{}})
+	assert.Equal(t, len(slices.Collect(Product(slices.Values([]int{1}), slices.Values([]int{})))), 0)
+}
+
+func TestCombinations(t *testing.T) {
+	it := slices.Values([]string{"a", "b", "c", "d"})
+
+	assert.DeepEqual(t, slices.Collect(Combinations(it, 2)),
+		[][]string{{"a", "b"}, {"a", "c"}, {"a", "d"}, {"b", "c"}, {"b", "d"}, {"c", "d"}})
+	assert.DeepEqual(t, slices.Collect(Combinations(it, 4)), [][]string{{"a", "b", "c", "d"}})
+	assert.Equal(t, len(slices.Collect(Combinations(it, 5))), 0)
+}
+
+func TestCombinationsWithReplacement(t *testing.T) {
+	it := slices.Values([]string{"a", "b", "c"})
+
+	assert.DeepEqual(t, slices.Collect(CombinationsWithReplacement(it, 2)),
+		[][]string{{"a", "a"}, {"a", "b"}, {"a", "c"}, {"b", "b"}, {"b", "c"}, {"c", "c"}})
+}
+
+func TestPermutations(t *testing.T) {
+	it := slices.Values([]int{1, 2, 3})
+
+	assert.DeepEqual(t, slices.Collect(Permutations(it, 3)),
+		[][]int{{1, 2, 3}, {1, 3, 2}, {2, 1, 3}, {2, 3, 1}, {3, 1, 2}, {3, 2, 1}})
+	assert.DeepEqual(t, slices.Collect(Permutations(it, 2)),
+		[][]int{{1, 2}, {1, 3}, {2, 1}, {2, 3}, {3, 1}, {3, 2}})
+}
+
+func TestCombinatorics_Reuse(t *testing.T) {
+	it := slices.Values([]int{1, 2, 3, 4})
+
+	var sums []int
+	var prev []int
+	for c := range CombinationsReuse(it, 2) {
+		if prev != nil {
+			assert.Equal(t, &prev[0], &c[0])
+		}
+		sums = append(sums, c[0]+c[1])
+		prev = c
+	}
+	assert.DeepEqual(t, sums, []int{3, 4, 5, 5, 6, 7})
+
+	n := 0
+	for range Filter(PermutationsReuse(it, 4), func(p []int) bool { return p[0] < p[3] }) {
+		n++
+	}
+	assert.Equal(t, n, 12)
+}
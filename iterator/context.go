@@ -0,0 +1,45 @@
+package iterator
+
+import "context"
+
+// WithContext returns a fallible iterator that yields the elements of it with a nil error until ctx is done. If ctx
+// cuts the iteration short, a final zero value is yielded with ctx.Err().
+func WithContext[T any](ctx context.Context, it func(func(T) bool)) func(func(T, error) bool) {
+	return func(yield func(T, error) bool) {
+		for t := range it {
+			select {
+			case <-ctx.Done():
+				var zero T
+				yield(zero, ctx.Err())
+				return
+			default:
+			}
+			if !yield(t, nil) {
+				return
+			}
+		}
+	}
+}
+
+// MapContext is like Map, but stops once ctx is done. Like WithContext, it returns a fallible iterator whose last
+// element carries ctx.Err() if ctx cut the iteration short.
+func MapContext[T, V any](ctx context.Context, it func(func(T) bool), fn func(T) V) func(func(V, error) bool) {
+	return MapErr(WithContext(ctx, it), func(t T) (V, error) {
+		return fn(t), nil
+	})
+}
+
+// ReduceContext is like Reduce, but stops once ctx is done. It returns the partial result along with ctx.Err() if ctx
+// cut the reduction short, and nil if every element of it was reduced.
+func ReduceContext[T, V any](ctx context.Context, it func(func(T) bool), fn func(V, T) V, init V) (V, error) {
+	acc := init
+	for t := range it {
+		select {
+		case <-ctx.Done():
+			return acc, ctx.Err()
+		default:
+		}
+		acc = fn(acc, t)
+	}
+	return acc, nil
+}
@@ -0,0 +1,91 @@
+package iterator
+
+import (
+	"context"
+	"gotest.tools/v3/assert"
+	"slices"
+	"strconv"
+	"testing"
+)
+
+func TestWithContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	it := WithContext(ctx, slices.Values([]int{1, 2, 3, 4}))
+
+	var result []int
+	var errs []error
+	for n, err := range it {
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		result = append(result, n)
+		if n == 2 {
+			cancel()
+		}
+	}
+	assert.DeepEqual(t, result, []int{1, 2})
+	assert.Equal(t, len(errs), 1)
+	assert.ErrorIs(t, errs[0], context.Canceled)
+}
+
+func TestWithContext_Break(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// stopping early is not reported as an error, even if ctx is done afterwards
+	for _, err := range WithContext(ctx, slices.Values([]int{1, 2, 3})) {
+		assert.NilError(t, err)
+		cancel()
+		break
+	}
+}
+
+func TestMapContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	result, err := TryCollect(MapContext(ctx, slices.Values([]int{1, 2, 3}), strconv.Itoa))
+	assert.NilError(t, err)
+	assert.DeepEqual(t, result, []string{"1", "2", "3"})
+
+	it := MapContext(ctx, slices.Values([]int{1, 2, 3}), func(n int) string {
+		if n == 2 {
+			cancel()
+		}
+		return strconv.Itoa(n)
+	})
+	result, err = TryCollect(it)
+	assert.DeepEqual(t, result, []string{"1", "2"})
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func TestReduceContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	it := Generate(1, func(x int) int {
+		if x == 4 {
+			cancel()
+		}
+		return x + 1
+	})
+
+	result, err := ReduceContext(ctx, it, func(acc, n int) int { return acc + n }, 0)
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, result, 10)
+
+	result, err = ReduceContext(context.Background(), slices.Values([]int{1, 2}), func(acc, n int) int { return acc + n }, 0)
+	assert.NilError(t, err)
+	assert.Equal(t, result, 3)
+
+	// ctx being done after the last element is reduced does not count as cutting the reduction short
+	ctx, cancel = context.WithCancel(context.Background())
+	result, err = ReduceContext(ctx, slices.Values([]int{1, 2}), func(acc, n int) int {
+		if n == 2 {
+			cancel()
+		}
+		return acc + n
+	}, 0)
+	assert.NilError(t, err)
+	assert.Equal(t, result, 3)
+}
@@ -0,0 +1,105 @@
+package iterator
+
+import (
+	"go-exp/functions"
+	"hash/maphash"
+	"math"
+)
+
+// Distinct returns an iterator that yields the first occurrence of each element of it
+func Distinct[T comparable](it func(func(T) bool)) func(func(T) bool) {
+	return DistinctBy(it, functions.Identity[T])
+}
+
+// DistinctBy returns an iterator that yields the first element of it for each key returned by keyFn
+func DistinctBy[T any, K comparable](it func(func(T) bool), keyFn func(T) K) func(func(T) bool) {
+	return func(yield func(T) bool) {
+		seen := make(map[K]struct{})
+		for t := range it {
+			k := keyFn(t)
+			if _, ok := seen[k]; ok {
+				continue
+			}
+			seen[k] = struct{}{}
+			if !yield(t) {
+				break
+			}
+		}
+	}
+}
+
+type BloomOptions struct {
+	// ExpectedItems is the number of distinct keys the filter is sized for
+	ExpectedItems int
+	// FalsePositiveRate is the target probability of treating an unseen key as seen once ExpectedItems keys have been
+	// added
+	FalsePositiveRate float64
+	// MaxBytes caps the size of the filter, at the cost of a higher false positive rate. Zero means no cap.
+	MaxBytes int
+}
+
+// DistinctBloom is like DistinctBy, but tracks the keys it has seen in a Bloom filter of bounded size. It never
+// yields a duplicate, but may drop an element whose key has not been seen before, with a probability given by
+// opts.FalsePositiveRate.
+func DistinctBloom[T any](it func(func(T) bool), keyFn func(T) string, opts BloomOptions) func(func(T) bool) {
+	return func(yield func(T) bool) {
+		filter := newBloomFilter(opts)
+		for t := range it {
+			if !filter.add(keyFn(t)) {
+				continue
+			}
+			if !yield(t) {
+				break
+			}
+		}
+	}
+}
+
+type bloomFilter struct {
+	bits  []uint64
+	m     uint64
+	k     int
+	seed1 maphash.Seed
+	seed2 maphash.Seed
+}
+
+func newBloomFilter(opts BloomOptions) *bloomFilter {
+	n := float64(max(opts.ExpectedItems, 1))
+	p := opts.FalsePositiveRate
+	if p <= 0 || p >= 1 {
+		panic("iterator: bloom filter false positive rate must be between 0 and 1")
+	}
+
+	m := math.Ceil(-n * math.Log(p) / (math.Ln2 * math.Ln2))
+	if opts.MaxBytes > 0 {
+		m = min(m, float64(opts.MaxBytes)*8)
+	}
+	m = max(m, 64)
+	k := max(int(math.Round(m/n*math.Ln2)), 1)
+
+	words := (uint64(m) + 63) / 64
+	return &bloomFilter{
+		bits:  make([]uint64, words),
+		m:     words * 64,
+		k:     k,
+		seed1: maphash.MakeSeed(),
+		seed2: maphash.MakeSeed(),
+	}
+}
+
+// add adds key to the filter and reports whether it was absent before
+func (f *bloomFilter) add(key string) bool {
+	h1 := maphash.String(f.seed1, key)
+	h2 := maphash.String(f.seed2, key) | 1
+
+	added := false
+	for i := range f.k {
+		bit := (h1 + uint64(i)*h2) % f.m
+		word, mask := bit/64, uint64(1)<<(bit%64)
+		if f.bits[word]&mask == 0 {
+			f.bits[word] |= mask
+			added = true
+		}
+	}
+	return added
+}
@@ -0,0 +1,35 @@
+package iterator
+
+import (
+	"gotest.tools/v3/assert"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestDistinct(t *testing.T) {
+	it := slices.Values([]int{3, 1, 3, 2, 1, 4, 2})
+	assert.DeepEqual(t, slices.Collect(Distinct(it)), []int{3, 1, 2, 4})
+}
+
+func TestDistinctBy(t *testing.T) {
+	it := slices.Values([]string{"Go", "rust", "GO", "Rust", "zig"})
+	assert.DeepEqual(t, slices.Collect(DistinctBy(it, strings.ToLower)), []string{"Go", "rust", "zig"})
+}
+
+func TestDistinctBloom(t *testing.T) {
+	n := 10000
+	// every number appears twice
+	it := Map(Range(0, 2*n, 1), func(i int) int { return i % n })
+	opts := BloomOptions{ExpectedItems: n, FalsePositiveRate: 0.01}
+
+	result := slices.Collect(DistinctBloom(it, strconv.Itoa, opts))
+	assert.DeepEqual(t, result, slices.Collect(Distinct(slices.Values(result))))
+	assert.Assert(t, len(result) > n*95/100, "too many false positives: %d", n-len(result))
+}
+
+func TestDistinctBloom_MaxBytes(t *testing.T) {
+	filter := newBloomFilter(BloomOptions{ExpectedItems: 1000000, FalsePositiveRate: 0.001, MaxBytes: 1024})
+	assert.Equal(t, len(filter.bits)*8, 1024)
+}
@@ -0,0 +1,112 @@
+package iterator
+
+import (
+	"errors"
+	"io"
+)
+
+// FromFallible returns a fallible iterator, which yields each element paired with an error, over the results of
+// calling next until it returns io.EOF. Any other error is yielded and ends the iteration.
+func FromFallible[T any](next func() (T, error)) func(func(T, error) bool) {
+	return func(yield func(T, error) bool) {
+		for {
+			t, err := next()
+			if errors.Is(err, io.EOF) {
+				return
+			}
+			if !yield(t, err) || err != nil {
+				return
+			}
+		}
+	}
+}
+
+// Infallible returns a fallible iterator that yields the elements of it with a nil error
+func Infallible[T any](it func(func(T) bool)) func(func(T, error) bool) {
+	return func(yield func(T, error) bool) {
+		for t := range it {
+			if !yield(t, nil) {
+				break
+			}
+		}
+	}
+}
+
+// MapErr returns a fallible iterator that applies fn to the elements of it. Errors from it are passed through
+// untouched without calling fn, and an error from fn is yielded in place of the element.
+func MapErr[T, V any](it func(func(T, error) bool), fn func(T) (V, error)) func(func(V, error) bool) {
+	return func(yield func(V, error) bool) {
+		for t, err := range it {
+			var v V
+			if err == nil {
+				v, err = fn(t)
+			}
+			if !yield(v, err) {
+				break
+			}
+		}
+	}
+}
+
+// FilterErr returns a fallible iterator over the elements of it for which fn returns true. Errors from it are passed
+// through untouched without calling fn, and an element for which fn fails is yielded along with the error.
+func FilterErr[T any](it func(func(T, error) bool), fn func(T) (bool, error)) func(func(T, error) bool) {
+	return func(yield func(T, error) bool) {
+		for t, err := range it {
+			keep := true
+			if err == nil {
+				keep, err = fn(t)
+			}
+			if (keep || err != nil) && !yield(t, err) {
+				break
+			}
+		}
+	}
+}
+
+// TryReduce reduces the elements of it with fn, stopping at the first error from either
+func TryReduce[T, V any](it func(func(T, error) bool), fn func(V, T) (V, error), init V) (V, error) {
+	acc := init
+	for t, err := range it {
+		if err != nil {
+			return acc, err
+		}
+		if acc, err = fn(acc, t); err != nil {
+			return acc, err
+		}
+	}
+	return acc, nil
+}
+
+// TryReduceAll reduces the elements of it with fn, skipping failed elements and returning all the errors joined with
+// errors.Join
+func TryReduceAll[T, V any](it func(func(T, error) bool), fn func(V, T) (V, error), init V) (V, error) {
+	acc := init
+	var errs []error
+	for t, err := range it {
+		if err == nil {
+			var v V
+			if v, err = fn(acc, t); err == nil {
+				acc = v
+				continue
+			}
+		}
+		errs = append(errs, err)
+	}
+	return acc, errors.Join(errs...)
+}
+
+// TryCollect collects the elements of it into a slice, stopping at the first error
+func TryCollect[T any](it func(func(T, error) bool)) ([]T, error) {
+	return TryReduce(it, appendNoErr[T], nil)
+}
+
+// TryCollectAll collects the elements of it into a slice, skipping failed elements and returning all the errors
+// joined with errors.Join
+func TryCollectAll[T any](it func(func(T, error) bool)) ([]T, error) {
+	return TryReduceAll(it, appendNoErr[T], nil)
+}
+
+func appendNoErr[T any](s []T, t T) ([]T, error) {
+	return append(s, t), nil
+}
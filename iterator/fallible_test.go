@@ -0,0 +1,85 @@
+package iterator
+
+import (
+	"errors"
+	"gotest.tools/v3/assert"
+	"io"
+	"slices"
+	"strconv"
+	"testing"
+)
+
+func TestFromFallible(t *testing.T) {
+	s := []string{"1", "2", "3"}
+	i := 0
+	it := FromFallible(func() (string, error) {
+		if i == len(s) {
+			return "", io.EOF
+		}
+		i++
+		return s[i-1], nil
+	})
+
+	result, err := TryCollect(it)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, result, s)
+}
+
+func TestFromFallible_Error(t *testing.T) {
+	e := errors.New("read failed")
+	calls := 0
+	it := FromFallible(func() (int, error) {
+		calls++
+		return 0, e
+	})
+
+	result, err := TryCollectAll(it)
+	assert.ErrorIs(t, err, e)
+	assert.Equal(t, len(result), 0)
+	assert.Equal(t, calls, 1)
+}
+
+func TestMapErr(t *testing.T) {
+	it := MapErr(Infallible(slices.Values([]string{"1", "x", "3", "y"})), strconv.Atoi)
+
+	result, err := TryCollect(it)
+	assert.ErrorContains(t, err, `"x"`)
+	assert.DeepEqual(t, result, []int{1})
+
+	result, err = TryCollectAll(it)
+	assert.ErrorContains(t, err, `"x"`)
+	assert.ErrorContains(t, err, `"y"`)
+	assert.DeepEqual(t, result, []int{1, 3})
+}
+
+func TestFilterErr(t *testing.T) {
+	e := errors.New("negative")
+	it := FilterErr(Infallible(slices.Values([]int{1, 2, -3, 4})), func(n int) (bool, error) {
+		if n < 0 {
+			return false, e
+		}
+		return n%2 == 0, nil
+	})
+
+	result, err := TryCollectAll(it)
+	assert.ErrorIs(t, err, e)
+	assert.DeepEqual(t, result, []int{2, 4})
+}
+
+func TestTryReduce(t *testing.T) {
+	it := MapErr(Infallible(slices.Values([]string{"1", "2", "3"})), strconv.Atoi)
+	sum := func(acc, n int) (int, error) {
+		if acc+n > 5 {
+			return acc, errors.New("overflow")
+		}
+		return acc + n, nil
+	}
+
+	result, err := TryReduce(it, sum, 0)
+	assert.ErrorContains(t, err, "overflow")
+	assert.Equal(t, result, 3)
+
+	result, err = TryReduceAll(it, sum, 1)
+	assert.ErrorContains(t, err, "overflow")
+	assert.Equal(t, result, 4)
+}
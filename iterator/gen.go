@@ -1,8 +1,99 @@
 package iterator
 
+import (
+	"context"
+	"go-exp/constraints"
+)
+
 func Generate[T any](init T, gen func(T) T) func(func(T) bool) {
 	return func(yield func(T) bool) {
 		for current := init; yield(current); current = gen(current) {
 		}
 	}
 }
+
+// GenerateContext is like Generate, but stops once ctx is done. Like WithContext, it returns a fallible iterator whose
+// last element carries ctx.Err() once ctx ends the iteration.
+func GenerateContext[T any](ctx context.Context, init T, gen func(T) T) func(func(T, error) bool) {
+	return WithContext(ctx, Generate(init, gen))
+}
+
+// Range returns an iterator over start, start+step, start+2*step, ... up to but excluding stop. Each value is computed
+// from start rather than by repeated addition, so float ranges don't accumulate rounding errors.
+func Range[T constraints.Real](start, stop, step T) func(func(T) bool) {
+	if step == 0 {
+		panic("iterator: range step must not be zero")
+	}
+	return func(yield func(T) bool) {
+		prev := start
+		for i := 0; ; i++ {
+			v := start + T(i)*step
+			// stop if the next value would overflow or no longer change
+			if i > 0 && (step > 0) != (v > prev) {
+				return
+			}
+			if step > 0 && v >= stop || step < 0 && v <= stop || !yield(v) {
+				return
+			}
+			prev = v
+		}
+	}
+}
+
+// Repeat returns an iterator that yields v forever
+func Repeat[T any](v T) func(func(T) bool) {
+	return func(yield func(T) bool) {
+		for yield(v) {
+		}
+	}
+}
+
+// RepeatN returns an iterator that yields v n times
+func RepeatN[T any](v T, n int) func(func(T) bool) {
+	return func(yield func(T) bool) {
+		for range n {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// Cycle returns an iterator that yields the elements of the finite iterator it, then replays them forever. The
+// elements are buffered during the first pass, so it is only iterated once.
+func Cycle[T any](it func(func(T) bool)) func(func(T) bool) {
+	return func(yield func(T) bool) {
+		var buf []T
+		for t := range it {
+			if !yield(t) {
+				return
+			}
+			buf = append(buf, t)
+		}
+		if len(buf) == 0 {
+			return
+		}
+		for {
+			for _, t := range buf {
+				if !yield(t) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// Unfold returns an iterator that repeatedly applies fn to a state, starting from seed. fn returns the value to yield,
+// the next state, and whether to continue; iteration ends as soon as it returns false.
+func Unfold[S, T any](seed S, fn func(S) (T, S, bool)) func(func(T) bool) {
+	return func(yield func(T) bool) {
+		state := seed
+		for {
+			t, next, ok := fn(state)
+			if !ok || !yield(t) {
+				return
+			}
+			state = next
+		}
+	}
+}
@@ -1,6 +1,7 @@
 package iterator
 
 import (
+	"context"
 	"gotest.tools/v3/assert"
 	"slices"
 	"testing"
@@ -14,3 +15,54 @@ func TestGenerate(t *testing.T) {
 
 	assert.DeepEqual(t, result, expected)
 }
+
+func TestGenerateContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	it := GenerateContext(ctx, 0, func(x int) int {
+		if x == 3 {
+			cancel()
+		}
+		return x + 1
+	})
+
+	result, err := TryCollect(it)
+	assert.DeepEqual(t, result, []int{0, 1, 2, 3})
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func TestRange(t *testing.T) {
+	assert.DeepEqual(t, slices.Collect(Range(0, 10, 3)), []int{0, 3, 6, 9})
+	assert.DeepEqual(t, slices.Collect(Range(5, 0, -2)), []int{5, 3, 1})
+	assert.DeepEqual(t, slices.Collect(Range(0, 0, 1)), []int(nil))
+	assert.DeepEqual(t, slices.Collect(Range[int8](120, 127, 5)), []int8{120, 125})
+
+	floats := slices.Collect(Range(0, 1, 0.1))
+	assert.Equal(t, len(floats), 10)
+	assert.Equal(t, floats[9], 0.9)
+}
+
+func TestRepeat(t *testing.T) {
+	result := slices.Collect(ZipWith(Range(0, 3, 1), Repeat("a"), func(_ int, s string) string { return s }))
+	assert.DeepEqual(t, result, []string{"a", "a", "a"})
+}
+
+func TestRepeatN(t *testing.T) {
+	assert.DeepEqual(t, slices.Collect(RepeatN("a", 3)), []string{"a", "a", "a"})
+	assert.DeepEqual(t, slices.Collect(RepeatN("a", 0)), []string(nil))
+}
+
+func TestCycle(t *testing.T) {
+	it := Cycle(slices.Values([]int{1, 2, 3}))
+	result := slices.Collect(ZipWith(Range(0, 7, 1), it, func(_ int, n int) int { return n }))
+	assert.DeepEqual(t, result, []int{1, 2, 3, 1, 2, 3, 1})
+
+	assert.DeepEqual(t, slices.Collect(Cycle(slices.Values([]int{}))), []int(nil))
+}
+
+func TestUnfold(t *testing.T) {
+	// fibonacci numbers below 50
+	it := Unfold([2]int{0, 1}, func(s [2]int) (int, [2]int, bool) {
+		return s[0], [2]int{s[1], s[0] + s[1]}, s[0] < 50
+	})
+	assert.DeepEqual(t, slices.Collect(it), []int{0, 1, 1, 2, 3, 5, 8, 13, 21, 34})
+}
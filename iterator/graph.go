@@ -0,0 +1,220 @@
+package iterator
+
+import (
+	"errors"
+	"fmt"
+	"iter"
+	"slices"
+)
+
+var ErrCycle = errors.New("iterator: graph has a cycle")
+
+// BFS returns an iterator over the nodes of the tree rooted at root in breadth-first order. The children of a node are
+// only read once the traversal reaches it. Nodes reachable along several paths are yielded once per path, and cycles
+// never end, so use BFSBy on graphs.
+func BFS[T any](root T, children func(T) func(func(T) bool)) func(func(T) bool) {
+	return bfs(root, children, alwaysFirstVisit[T])
+}
+
+// BFSBy is like BFS, but skips nodes whose key has already been seen, so it is safe to use on graphs with shared
+// nodes or cycles
+func BFSBy[T any, K comparable](root T, children func(T) func(func(T) bool), key func(T) K) func(func(T) bool) {
+	return func(yield func(T) bool) {
+		bfs(root, children, firstVisitBy(key))(yield)
+	}
+}
+
+// DFS returns an iterator over the nodes of the tree rooted at root in depth-first pre-order. Like BFS, it reads
+// children lazily and revisits shared nodes, so use DFSBy on graphs.
+func DFS[T any](root T, children func(T) func(func(T) bool)) func(func(T) bool) {
+	return dfsPreOrder(root, children, alwaysFirstVisit[T])
+}
+
+// DFSBy is like DFS, but skips nodes whose key has already been seen
+func DFSBy[T any, K comparable](root T, children func(T) func(func(T) bool), key func(T) K) func(func(T) bool) {
+	return func(yield func(T) bool) {
+		dfsPreOrder(root, children, firstVisitBy(key))(yield)
+	}
+}
+
+// DFSPostOrder returns an iterator over the nodes of the tree rooted at root in depth-first post-order, so every node
+// comes after its children. Like BFS, it reads children lazily and revisits shared nodes, so use DFSPostOrderBy on
+// graphs.
+func DFSPostOrder[T any](root T, children func(T) func(func(T) bool)) func(func(T) bool) {
+	return dfsPostOrder(root, children, alwaysFirstVisit[T])
+}
+
+// DFSPostOrderBy is like DFSPostOrder, but skips nodes whose key has already been seen
+func DFSPostOrderBy[T any, K comparable](root T, children func(T) func(func(T) bool), key func(T) K) func(func(T) bool) {
+	return func(yield func(T) bool) {
+		dfsPostOrder(root, children, firstVisitBy(key))(yield)
+	}
+}
+
+// TopoSort returns a fallible iterator over nodes and the nodes reachable from them through edges, where each node
+// comes after the nodes that its edges lead to. Nodes are identified by key. If the graph has a cycle, an error
+// wrapping ErrCycle is yielded once the cycle is reached and iteration ends.
+func TopoSort[T any, K comparable](nodes func(func(T) bool), edges func(T) func(func(T) bool), key func(T) K) func(func(T, error) bool) {
+	return func(yield func(T, error) bool) {
+		done := make(map[K]struct{})
+		inPath := make(map[K]int)
+		var path []T
+		var err error
+
+		enter := func(node T) walkAction {
+			k := key(node)
+			if _, ok := done[k]; ok {
+				return walkSkip
+			}
+			if i, ok := inPath[k]; ok {
+				err = fmt.Errorf("%w: %v", ErrCycle, append(slices.Clone(path[i:]), node))
+				return walkAbort
+			}
+			inPath[k] = len(path)
+			path = append(path, node)
+			return walkDescend
+		}
+		exit := func(node T) bool {
+			k := key(node)
+			delete(inPath, k)
+			path = path[:len(path)-1]
+			done[k] = struct{}{}
+			return yield(node, nil)
+		}
+
+		for node := range nodes {
+			if !walkDepthFirst(node, edges, enter, exit) {
+				if err != nil {
+					var zero T
+					yield(zero, err)
+				}
+				return
+			}
+		}
+	}
+}
+
+func alwaysFirstVisit[T any](T) bool {
+	return true
+}
+
+// firstVisitBy returns a function that reports whether a node's key is being seen for the first time
+func firstVisitBy[T any, K comparable](key func(T) K) func(T) bool {
+	seen := make(map[K]struct{})
+	return func(t T) bool {
+		k := key(t)
+		if _, ok := seen[k]; ok {
+			return false
+		}
+		seen[k] = struct{}{}
+		return true
+	}
+}
+
+func bfs[T any](root T, children func(T) func(func(T) bool), firstVisit func(T) bool) func(func(T) bool) {
+	return func(yield func(T) bool) {
+		if !firstVisit(root) {
+			return
+		}
+		queue := []T{root}
+		for len(queue) > 0 {
+			node := queue[0]
+			var zero T
+			queue[0] = zero
+			queue = queue[1:]
+			if !yield(node) {
+				return
+			}
+			for child := range children(node) {
+				if firstVisit(child) {
+					queue = append(queue, child)
+				}
+			}
+		}
+	}
+}
+
+func dfsPreOrder[T any](root T, children func(T) func(func(T) bool), firstVisit func(T) bool) func(func(T) bool) {
+	return func(yield func(T) bool) {
+		walkDepthFirst(root, children, func(node T) walkAction {
+			if !firstVisit(node) {
+				return walkSkip
+			}
+			if !yield(node) {
+				return walkAbort
+			}
+			return walkDescend
+		}, func(T) bool { return true })
+	}
+}
+
+func dfsPostOrder[T any](root T, children func(T) func(func(T) bool), firstVisit func(T) bool) func(func(T) bool) {
+	return func(yield func(T) bool) {
+		walkDepthFirst(root, children, func(node T) walkAction {
+			if !firstVisit(node) {
+				return walkSkip
+			}
+			return walkDescend
+		}, yield)
+	}
+}
+
+type walkAction int
+
+const (
+	walkDescend walkAction = iota
+	walkSkip
+	walkAbort
+)
+
+type dfsFrame[T any] struct {
+	node T
+	next func() (T, bool)
+	stop func()
+}
+
+// walkDepthFirst walks the graph reachable from root depth first, pulling the children of each node only when they
+// are needed. enter is called when a node is reached and decides whether to descend into it, and exit is called once
+// all the children of a node that was descended into have been walked. It returns false if the walk was aborted by
+// either of them.
+func walkDepthFirst[T any](root T, children func(T) func(func(T) bool), enter func(T) walkAction, exit func(T) bool) bool {
+	var stack []dfsFrame[T]
+	defer func() {
+		for _, frame := range stack {
+			frame.stop()
+		}
+	}()
+	push := func(node T) {
+		next, stop := iter.Pull(children(node))
+		stack = append(stack, dfsFrame[T]{node, next, stop})
+	}
+
+	switch enter(root) {
+	case walkSkip:
+		return true
+	case walkAbort:
+		return false
+	}
+	push(root)
+
+	for len(stack) > 0 {
+		top := stack[len(stack)-1]
+		child, ok := top.next()
+		if !ok {
+			top.stop()
+			stack = stack[:len(stack)-1]
+			if !exit(top.node) {
+				return false
+			}
+			continue
+		}
+
+		switch enter(child) {
+		case walkDescend:
+			push(child)
+		case walkAbort:
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,73 @@
+package iterator
+
+import (
+	"go-exp/functions"
+	"gotest.tools/v3/assert"
+	"slices"
+	"testing"
+)
+
+// a tree where 1 has children 2 and 3, 2 has children 4 and 5, and 3 has child 6
+var tree = map[int][]int{1: {2, 3}, 2: {4, 5}, 3: {6}}
+
+func treeChildren(n int) func(func(int) bool) {
+	return slices.Values(tree[n])
+}
+
+// graph with a shared node and a cycle: a -> b, a -> c, b -> d, c -> d, d -> a
+var graph = map[string][]string{"a": {"b", "c"}, "b": {"d"}, "c": {"d"}, "d": {"a"}}
+
+func graphChildren(s string) func(func(string) bool) {
+	return slices.Values(graph[s])
+}
+
+func TestBFS(t *testing.T) {
+	assert.DeepEqual(t, slices.Collect(BFS(1, treeChildren)), []int{1, 2, 3, 4, 5, 6})
+	assert.DeepEqual(t, slices.Collect(BFSBy("a", graphChildren, functions.Identity[string])), []string{"a", "b", "c", "d"})
+}
+
+func TestDFS(t *testing.T) {
+	assert.DeepEqual(t, slices.Collect(DFS(1, treeChildren)), []int{1, 2, 4, 5, 3, 6})
+	assert.DeepEqual(t, slices.Collect(DFSBy("a", graphChildren, functions.Identity[string])), []string{"a", "b", "d", "c"})
+}
+
+func TestDFSPostOrder(t *testing.T) {
+	assert.DeepEqual(t, slices.Collect(DFSPostOrder(1, treeChildren)), []int{4, 5, 2, 6, 3, 1})
+	assert.DeepEqual(t, slices.Collect(DFSPostOrderBy("a", graphChildren, functions.Identity[string])), []string{"d", "b", "c", "a"})
+}
+
+func TestDFS_Lazy(t *testing.T) {
+	// an infinite binary tree
+	children := func(n int) func(func(int) bool) {
+		return slices.Values([]int{2 * n, 2*n + 1})
+	}
+
+	first, ok := First(Filter(DFS(1, children), func(n int) bool { return n > 100 }))
+	assert.Assert(t, ok)
+	assert.Equal(t, first, 128)
+
+	result := slices.Collect(TakeWhile(BFS(1, children), func(n int) bool { return n < 8 }))
+	assert.DeepEqual(t, result, []int{1, 2, 3, 4, 5, 6, 7})
+}
+
+func TestTopoSort(t *testing.T) {
+	deps := map[string][]string{
+		"app":    {"http", "db"},
+		"http":   {"log"},
+		"db":     {"log", "config"},
+		"log":    {"config"},
+		"config": nil,
+	}
+	edges := func(s string) func(func(string) bool) { return slices.Values(deps[s]) }
+
+	result, err := TryCollect(TopoSort(slices.Values([]string{"app", "config"}), edges, functions.Identity[string]))
+	assert.NilError(t, err)
+	assert.DeepEqual(t, result, []string{"config", "log", "http", "db", "app"})
+}
+
+func TestTopoSort_Cycle(t *testing.T) {
+	result, err := TryCollect(TopoSort(slices.Values([]string{"a"}), graphChildren, functions.Identity[string]))
+	assert.ErrorIs(t, err, ErrCycle)
+	assert.ErrorContains(t, err, "[a b d a]")
+	assert.Equal(t, len(result), 0)
+}
@@ -0,0 +1,53 @@
+package iterator
+
+import "iter"
+
+// GroupAdjacent returns an iterator that yields runs of consecutive elements with equal keys, along with their key.
+// Each group is a single-use iterator that reads directly from it, so it must be consumed before the outer iterator
+// advances; elements of a group that are not consumed are skipped.
+func GroupAdjacent[T any, K comparable](it func(func(T) bool), keyFn func(T) K) func(func(K, func(func(T) bool)) bool) {
+	return func(yield func(K, func(func(T) bool)) bool) {
+		next, stop := iter.Pull(it)
+		defer stop()
+
+		t, ok := next()
+		var k K
+		if ok {
+			k = keyFn(t)
+		}
+		for ok {
+			groupKey := k
+			// boundary is set once t holds the first element of the next group, or it is exhausted
+			boundary := false
+			advance := func() {
+				if t, ok = next(); !ok {
+					boundary = true
+				} else if k = keyFn(t); k != groupKey {
+					boundary = true
+				}
+			}
+
+			used := false
+			group := func(yieldT func(T) bool) {
+				if used {
+					return
+				}
+				used = true
+				for !boundary {
+					if !yieldT(t) {
+						return
+					}
+					advance()
+				}
+			}
+
+			if !yield(groupKey, group) {
+				return
+			}
+			used = true
+			for !boundary {
+				advance()
+			}
+		}
+	}
+}
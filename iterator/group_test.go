@@ -0,0 +1,56 @@
+package iterator
+
+import (
+	"gotest.tools/v3/assert"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestGroupAdjacent(t *testing.T) {
+	it := slices.Values([]string{"apple", "avocado", "banana", "blueberry", "cherry", "apricot"})
+
+	var keys []byte
+	var groups [][]string
+	for k, group := range GroupAdjacent(it, func(s string) byte { return s[0] }) {
+		keys = append(keys, k)
+		groups = append(groups, slices.Collect(group))
+	}
+
+	assert.DeepEqual(t, keys, []byte("abca"))
+	assert.DeepEqual(t, groups, [][]string{{"apple", "avocado"}, {"banana", "blueberry"}, {"cherry"}, {"apricot"}})
+}
+
+func TestGroupAdjacent_PartialConsumption(t *testing.T) {
+	it := slices.Values([]int{1, 1, 1, 2, 2, 3, 3, 3})
+
+	var firsts []int
+	var stale []func(func(int) bool)
+	for k, group := range GroupAdjacent(it, func(n int) int { return n }) {
+		if k == 2 {
+			// skipped entirely
+			stale = append(stale, group)
+			continue
+		}
+		first, _ := First(group)
+		firsts = append(firsts, first)
+		// groups are single use
+		assert.Equal(t, len(slices.Collect(group)), 0)
+	}
+
+	assert.DeepEqual(t, firsts, []int{1, 3})
+	assert.Equal(t, len(slices.Collect(stale[0])), 0)
+}
+
+func TestGroupAdjacent_EarlyTermination(t *testing.T) {
+	it := Generate(0, func(n int) int { return n + 1 })
+
+	var groups []string
+	for k, group := range GroupAdjacent(it, func(n int) int { return n / 3 }) {
+		groups = append(groups, strings.Join(slices.Collect(Map(group, func(n int) string { return string(rune('a' + n)) })), ""))
+		if k == 2 {
+			break
+		}
+	}
+	assert.DeepEqual(t, groups, []string{"abc", "def", "ghi"})
+}
@@ -0,0 +1,222 @@
+// Package ioseq provides fallible iterators that read records from an io.Reader.
+package ioseq
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// DefaultMaxRecordSize is the record size cap used when a maxSize of zero is passed
+const DefaultMaxRecordSize = bufio.MaxScanTokenSize
+
+var ErrRecordTooLong = errors.New("ioseq: record exceeds maximum size")
+
+// PositionError records the position of the record an error occurred in
+type PositionError struct {
+	// Line is the 1-based line number that the record starts on
+	Line int
+	// Offset is the byte offset that the record starts at
+	Offset int64
+	Err    error
+}
+
+func (e *PositionError) Error() string {
+	return fmt.Sprintf("line %d, offset %d: %v", e.Line, e.Offset, e.Err)
+}
+
+func (e *PositionError) Unwrap() error {
+	return e.Err
+}
+
+type record struct {
+	data   []byte
+	line   int
+	offset int64
+}
+
+// scan splits r into records with split, tracking the position of each. The data of a yielded record is only valid
+// until the next iteration.
+func scan(r io.Reader, split bufio.SplitFunc, maxSize int) func(func(record, error) bool) {
+	if maxSize <= 0 {
+		maxSize = DefaultMaxRecordSize
+	}
+	return func(yield func(record, error) bool) {
+		var offset, next int64
+		line, nextLine := 1, 1
+		scanner := bufio.NewScanner(r)
+		// leave room for a \r\n terminator, which does not count towards maxSize
+		scanner.Buffer(make([]byte, 0, min(4096, maxSize+2)), maxSize+2)
+		scanner.Split(func(data []byte, atEOF bool) (int, []byte, error) {
+			advance, token, err := split(data, atEOF)
+			if len(token) > maxSize {
+				return 0, nil, ErrRecordTooLong
+			}
+			if advance > 0 {
+				next += int64(advance)
+				nextLine += bytes.Count(data[:advance], []byte{'\n'})
+			}
+			return advance, token, err
+		})
+
+		for scanner.Scan() {
+			if !yield(record{scanner.Bytes(), line, offset}, nil) {
+				return
+			}
+			offset, line = next, nextLine
+		}
+		if err := scanner.Err(); err != nil {
+			if errors.Is(err, bufio.ErrTooLong) {
+				err = ErrRecordTooLong
+			}
+			yield(record{line: line, offset: offset}, &PositionError{line, offset, err})
+		}
+	}
+}
+
+// Lines returns a fallible iterator over the lines of r, without their line endings. Lines longer than maxSize bytes,
+// not counting the line ending, end the iteration with ErrRecordTooLong.
+func Lines(r io.Reader, maxSize int) func(func(string, error) bool) {
+	return records(scan(r, bufio.ScanLines, maxSize))
+}
+
+// Delimited is like Lines, but splits r on delim
+func Delimited(r io.Reader, delim byte, maxSize int) func(func(string, error) bool) {
+	split := func(data []byte, atEOF bool) (int, []byte, error) {
+		if i := bytes.IndexByte(data, delim); i >= 0 {
+			return i + 1, data[:i], nil
+		}
+		if atEOF && len(data) > 0 {
+			return len(data), data, nil
+		}
+		return 0, nil, nil
+	}
+	return records(scan(r, split, maxSize))
+}
+
+func records(it func(func(record, error) bool)) func(func(string, error) bool) {
+	return func(yield func(string, error) bool) {
+		for rec, err := range it {
+			if !yield(string(rec.data), err) {
+				return
+			}
+		}
+	}
+}
+
+// JSONLines returns a fallible iterator that decodes each non-blank line of r as JSON into a T. A line that fails to
+// decode yields an error and iteration continues with the next line.
+func JSONLines[T any](r io.Reader, maxSize int) func(func(T, error) bool) {
+	return func(yield func(T, error) bool) {
+		for rec, err := range scan(r, bufio.ScanLines, maxSize) {
+			var t T
+			if err == nil {
+				if len(bytes.TrimSpace(rec.data)) == 0 {
+					continue
+				}
+				if jsonErr := json.Unmarshal(rec.data, &t); jsonErr != nil {
+					err = &PositionError{rec.line, rec.offset, jsonErr}
+				}
+			}
+			if !yield(t, err) {
+				return
+			}
+		}
+	}
+}
+
+// CSV returns a fallible iterator over the records of r, parsed with encoding/csv. configure, if not nil, is called
+// with the csv.Reader before reading so that its fields can be set. A record that fails to parse yields an error and
+// iteration continues with the next record, while records longer than maxSize bytes, not counting the line ending
+// after them, and read errors end the iteration.
+func CSV(r io.Reader, maxSize int, configure func(*csv.Reader)) func(func([]string, error) bool) {
+	if maxSize <= 0 {
+		maxSize = DefaultMaxRecordSize
+	}
+	return func(yield func([]string, error) bool) {
+		guard := &guardReader{r: r}
+		reader := csv.NewReader(guard)
+		if configure != nil {
+			configure(reader)
+		}
+
+		line := 1
+		var offset int64
+		for {
+			// csv.Reader reads ahead through a buffer, so allow for that on top of the record itself
+			guard.limit = offset + int64(maxSize) + 2 + 4096
+			fields, err := reader.Read()
+			if errors.Is(err, io.EOF) {
+				return
+			}
+
+			next := reader.InputOffset()
+			if err == nil && guard.recordSize(next-offset) > int64(maxSize) {
+				err = ErrRecordTooLong
+			}
+			stop := err != nil && !errors.As(err, new(*csv.ParseError))
+			if err != nil {
+				err = &PositionError{line, offset, err}
+			}
+			if !yield(fields, err) || stop {
+				return
+			}
+			line += guard.consume(next - offset)
+			offset = next
+		}
+	}
+}
+
+// guardReader fails with ErrRecordTooLong once more than limit bytes have been read. It keeps the bytes that have been
+// read but not yet consumed, so that the lines in a record can be counted.
+type guardReader struct {
+	r     io.Reader
+	read  int64
+	limit int64
+	// buf[start:] are the unconsumed bytes
+	buf   []byte
+	start int
+}
+
+// consume drops the first n unconsumed bytes and returns the number of newlines in them. The consumed bytes are only
+// removed from buf once they make up at least half of it, so that each byte is copied a bounded number of times.
+func (g *guardReader) consume(n int64) int {
+	end := g.start + int(n)
+	lines := bytes.Count(g.buf[g.start:end], []byte{'\n'})
+	g.start = end
+	if g.start == len(g.buf) {
+		g.buf, g.start = g.buf[:0], 0
+	} else if g.start >= len(g.buf)-g.start {
+		g.buf, g.start = append(g.buf[:0], g.buf[g.start:]...), 0
+	}
+	return lines
+}
+
+// recordSize returns the size of the record in the first n unconsumed bytes, without its line ending
+func (g *guardReader) recordSize(n int64) int64 {
+	rec := g.buf[g.start : g.start+int(n)]
+	if bytes.HasSuffix(rec, []byte{'\n'}) {
+		n--
+		if bytes.HasSuffix(rec[:n], []byte{'\r'}) {
+			n--
+		}
+	}
+	return n
+}
+
+func (g *guardReader) Read(p []byte) (int, error) {
+	if g.read >= g.limit {
+		return 0, ErrRecordTooLong
+	}
+	if int64(len(p)) > g.limit-g.read {
+		p = p[:g.limit-g.read]
+	}
+	n, err := g.r.Read(p)
+	g.read += int64(n)
+	g.buf = append(g.buf, p[:n]...)
+	return n, err
+}
@@ -0,0 +1,133 @@
+package ioseq
+
+import (
+	"encoding/csv"
+	"errors"
+	"go-exp/iterator"
+	"gotest.tools/v3/assert"
+	"strings"
+	"testing"
+)
+
+func TestLines(t *testing.T) {
+	r := strings.NewReader("one\r\ntwo\n\nthree")
+	result, err := iterator.TryCollect(Lines(r, 0))
+	assert.NilError(t, err)
+	assert.DeepEqual(t, result, []string{"one", "two", "", "three"})
+}
+
+func TestLines_TooLong(t *testing.T) {
+	r := strings.NewReader("short\nfine\nthis line is too long\nshort")
+	result, err := iterator.TryCollectAll(Lines(r, 10))
+	assert.DeepEqual(t, result, []string{"short", "fine"})
+	assert.ErrorIs(t, err, ErrRecordTooLong)
+
+	var posErr *PositionError
+	assert.Assert(t, errors.As(err, &posErr))
+	assert.Equal(t, posErr.Line, 3)
+	assert.Equal(t, posErr.Offset, int64(11))
+}
+
+func TestLines_MaxSize(t *testing.T) {
+	// line endings do not count towards maxSize
+	result, err := iterator.TryCollect(Lines(strings.NewReader("abcde\nfg\n"), 5))
+	assert.NilError(t, err)
+	assert.DeepEqual(t, result, []string{"abcde", "fg"})
+
+	result, err = iterator.TryCollect(Lines(strings.NewReader("abcde\r\nfg"), 5))
+	assert.NilError(t, err)
+	assert.DeepEqual(t, result, []string{"abcde", "fg"})
+
+	result, err = iterator.TryCollectAll(Lines(strings.NewReader("abcde\nabcdef\n"), 5))
+	assert.DeepEqual(t, result, []string{"abcde"})
+	assert.ErrorIs(t, err, ErrRecordTooLong)
+
+	result, err = iterator.TryCollect(Delimited(strings.NewReader("abcde,fg"), ',', 5))
+	assert.NilError(t, err)
+	assert.DeepEqual(t, result, []string{"abcde", "fg"})
+}
+
+func TestDelimited(t *testing.T) {
+	r := strings.NewReader("a,bb,,ccc")
+	result, err := iterator.TryCollect(Delimited(r, ',', 0))
+	assert.NilError(t, err)
+	assert.DeepEqual(t, result, []string{"a", "bb", "", "ccc"})
+}
+
+type event struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+func TestJSONLines(t *testing.T) {
+	r := strings.NewReader(`{"id": 1, "name": "a"}
+
+{"id": "two"}
+{"id": 3, "name": "c"}
+`)
+	result, err := iterator.TryCollectAll(JSONLines[event](r, 0))
+	assert.DeepEqual(t, result, []event{{1, "a"}, {3, "c"}})
+
+	var posErr *PositionError
+	assert.Assert(t, errors.As(err, &posErr))
+	assert.Equal(t, posErr.Line, 3)
+	assert.Equal(t, posErr.Offset, int64(24))
+}
+
+func TestCSV(t *testing.T) {
+	r := strings.NewReader("a;b\n\"multi\nline\";c\nd;e\n")
+	result, err := iterator.TryCollect(CSV(r, 0, func(r *csv.Reader) { r.Comma = ';' }))
+	assert.NilError(t, err)
+	assert.DeepEqual(t, result, [][]string{{"a", "b"}, {"multi\nline", "c"}, {"d", "e"}})
+}
+
+func TestCSV_Errors(t *testing.T) {
+	r := strings.NewReader("a,b\n\"x\nx\",y\nc\nd,e\n" + strings.Repeat("f", 50) + ",g\nh,i\n")
+	var records [][]string
+	var errs []*PositionError
+	for fields, err := range CSV(r, 40, nil) {
+		if err != nil {
+			var posErr *PositionError
+			assert.Assert(t, errors.As(err, &posErr))
+			errs = append(errs, posErr)
+			continue
+		}
+		records = append(records, fields)
+	}
+
+	assert.DeepEqual(t, records, [][]string{{"a", "b"}, {"x\nx", "y"}, {"d", "e"}})
+	assert.Equal(t, len(errs), 2)
+	assert.ErrorIs(t, errs[0], csv.ErrFieldCount)
+	assert.Equal(t, errs[0].Line, 4)
+	assert.Equal(t, errs[0].Offset, int64(12))
+	assert.ErrorIs(t, errs[1], ErrRecordTooLong)
+	assert.Equal(t, errs[1].Line, 6)
+	assert.Equal(t, errs[1].Offset, int64(18))
+}
+
+func TestCSV_MaxSize(t *testing.T) {
+	// line endings do not count towards maxSize
+	result, err := iterator.TryCollect(CSV(strings.NewReader("ab,cd\nef,gh\r\nij,kl"), 5, nil))
+	assert.NilError(t, err)
+	assert.DeepEqual(t, result, [][]string{{"ab", "cd"}, {"ef", "gh"}, {"ij", "kl"}})
+
+	result, err = iterator.TryCollectAll(CSV(strings.NewReader("ab,cd\nab,cde\n"), 5, nil))
+	assert.DeepEqual(t, result, [][]string{{"ab", "cd"}})
+	assert.ErrorIs(t, err, ErrRecordTooLong)
+}
+
+func TestCSV_ManyRecords(t *testing.T) {
+	r := strings.NewReader(strings.Repeat("abc,def\n", 10000))
+	result, err := iterator.TryCollect(CSV(r, 10, nil))
+	assert.NilError(t, err)
+	assert.Equal(t, len(result), 10000)
+
+	// positions stay correct as consumed input is dropped from the read-ahead buffer
+	r = strings.NewReader(strings.Repeat("abc,\"d\ne\"\n", 5000) + "bad\n")
+	result, err = iterator.TryCollectAll(CSV(r, 10, nil))
+	assert.Equal(t, len(result), 5000)
+	var posErr *PositionError
+	assert.Assert(t, errors.As(err, &posErr))
+	assert.Equal(t, posErr.Line, 10001)
+	assert.Equal(t, posErr.Offset, int64(5000*10))
+}
@@ -0,0 +1,65 @@
+package ioseq
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"io"
+)
+
+// WriteLines writes each element of it to w, followed by a newline. Writes are buffered, and the buffer is flushed every
+// flushEvery records if flushEvery is positive, and at the end. It returns the number of records known to have been
+// flushed to w along with the first error, and stops reading from it once an error occurs. On error the count is that
+// of the last successful flush, which is a lower bound: the buffer also flushes by itself when it fills up, so more
+// records may have reached w.
+func WriteLines[S ~string](w io.Writer, it func(func(S) bool), flushEvery int) (int, error) {
+	bw := bufio.NewWriter(w)
+	return writeAll(it, flushEvery, func(s S) error {
+		if _, err := bw.WriteString(string(s)); err != nil {
+			return err
+		}
+		return bw.WriteByte('\n')
+	}, bw.Flush)
+}
+
+// WriteCSV writes each element of it to w as a CSV record, buffering and flushing like WriteLines. configure, if not
+// nil, is called with the csv.Writer before writing so that its fields can be set.
+func WriteCSV(w io.Writer, it func(func([]string) bool), flushEvery int, configure func(*csv.Writer)) (int, error) {
+	cw := csv.NewWriter(w)
+	if configure != nil {
+		configure(cw)
+	}
+	return writeAll(it, flushEvery, cw.Write, func() error {
+		cw.Flush()
+		return cw.Error()
+	})
+}
+
+// WriteJSONL writes each element of it to w as a line of JSON, buffering and flushing like WriteLines
+func WriteJSONL[T any](w io.Writer, it func(func(T) bool), flushEvery int) (int, error) {
+	bw := bufio.NewWriter(w)
+	enc := json.NewEncoder(bw)
+	return writeAll(it, flushEvery, func(t T) error {
+		return enc.Encode(t)
+	}, bw.Flush)
+}
+
+func writeAll[T any](it func(func(T) bool), flushEvery int, write func(T) error, flush func() error) (int, error) {
+	n, flushed := 0, 0
+	for t := range it {
+		if err := write(t); err != nil {
+			return flushed, err
+		}
+		n++
+		if flushEvery > 0 && n%flushEvery == 0 {
+			if err := flush(); err != nil {
+				return flushed, err
+			}
+			flushed = n
+		}
+	}
+	if err := flush(); err != nil {
+		return flushed, err
+	}
+	return n, nil
+}
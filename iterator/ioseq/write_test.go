@@ -0,0 +1,83 @@
+package ioseq
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"go-exp/iterator"
+	"gotest.tools/v3/assert"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestWriteLines(t *testing.T) {
+	var buf bytes.Buffer
+	n, err := WriteLines(&buf, slices.Values([]string{"one", "two", "three"}), 0)
+	assert.NilError(t, err)
+	assert.Equal(t, n, 3)
+	assert.Equal(t, buf.String(), "one\ntwo\nthree\n")
+}
+
+// recordingWriter records the size of every write
+type recordingWriter struct {
+	writes []int
+	failAt int
+}
+
+func (w *recordingWriter) Write(p []byte) (int, error) {
+	if len(w.writes) == w.failAt {
+		return 0, errors.New("disk full")
+	}
+	w.writes = append(w.writes, len(p))
+	return len(p), nil
+}
+
+func TestWriteLines_FlushEvery(t *testing.T) {
+	w := &recordingWriter{failAt: -1}
+	n, err := WriteLines(w, slices.Values([]string{"a", "b", "c", "d", "e"}), 2)
+	assert.NilError(t, err)
+	assert.Equal(t, n, 5)
+	assert.DeepEqual(t, w.writes, []int{4, 4, 2})
+}
+
+func TestWriteLines_Error(t *testing.T) {
+	w := &recordingWriter{failAt: 1}
+	read := 0
+	it := iterator.Map(iterator.Range(0, 100, 1), func(i int) string {
+		read++
+		return "x"
+	})
+
+	// the first flush of 3 records succeeds and the second fails
+	n, err := WriteLines(w, it, 3)
+	assert.ErrorContains(t, err, "disk full")
+	assert.Equal(t, n, 3)
+	assert.Equal(t, read, 6)
+}
+
+func TestWriteCSV(t *testing.T) {
+	var buf bytes.Buffer
+	records := [][]string{{"a", "b c"}, {"multi\nline", `"q"`}}
+	n, err := WriteCSV(&buf, slices.Values(records), 1, func(w *csv.Writer) { w.Comma = ';' })
+	assert.NilError(t, err)
+	assert.Equal(t, n, 2)
+	assert.Equal(t, buf.String(), "a;b c\n\"multi\nline\";\"\"\"q\"\"\"\n")
+
+	result, err := iterator.TryCollect(CSV(&buf, 0, func(r *csv.Reader) { r.Comma = ';' }))
+	assert.NilError(t, err)
+	assert.DeepEqual(t, result, records)
+}
+
+func TestWriteJSONL(t *testing.T) {
+	var buf bytes.Buffer
+	events := []event{{1, "a"}, {2, "b"}}
+	n, err := WriteJSONL(&buf, slices.Values(events), 0)
+	assert.NilError(t, err)
+	assert.Equal(t, n, 2)
+	assert.Equal(t, strings.Count(buf.String(), "\n"), 2)
+
+	result, err := iterator.TryCollect(JSONLines[event](&buf, 0))
+	assert.NilError(t, err)
+	assert.DeepEqual(t, result, events)
+}
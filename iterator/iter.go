@@ -75,6 +75,65 @@ func ZipWith[T, U, V any](it func(func(T) bool), other func(func(U) bool), fn fu
 	}
 }
 
+// ZipLongest returns an iterator that yields pairs of elements from the two input iterators, terminating when both
+// iterators are exhausted. The element from an exhausted iterator is nil.
+func ZipLongest[T, U any](it func(func(T) bool), other func(func(U) bool)) func(func(*T, *U) bool) {
+	return func(yield func(*T, *U) bool) {
+		nextT, stopT := iter.Pull(it)
+		defer stopT()
+		nextU, stopU := iter.Pull(other)
+		defer stopU()
+		for {
+			t, okT := nextT()
+			u, okU := nextU()
+			if !okT && !okU {
+				return
+			}
+			var pt *T
+			if okT {
+				pt = &t
+			}
+			var pu *U
+			if okU {
+				pu = &u
+			}
+			if !yield(pt, pu) {
+				return
+			}
+		}
+	}
+}
+
+// ZipN returns an iterator that yields rows holding one element from each of the input iterators, terminating when
+// any iterator is exhausted
+func ZipN[T any](its []func(func(T) bool)) func(func([]T) bool) {
+	return func(yield func([]T) bool) {
+		if len(its) == 0 {
+			return
+		}
+		pulls := pullAll(its)
+		defer func() {
+			for _, it := range pulls {
+				it.stop()
+			}
+		}()
+
+		for {
+			row := make([]T, len(pulls))
+			for i, it := range pulls {
+				t, ok := it.next()
+				if !ok {
+					return
+				}
+				row[i] = t
+			}
+			if !yield(row) {
+				return
+			}
+		}
+	}
+}
+
 func Indexed[T any](it func(func(T) bool)) func(func(int, T) bool) {
 	return func(yield func(int, T) bool) {
 		i := 0
@@ -144,6 +203,62 @@ func GroupBy[T, K comparable](it func(func(T) bool), keyFn func(T) K) map[K][]T
 	return m
 }
 
+// AggregateBy reduces the elements of it that share a key into a single value per key. init is called for the initial
+// value of each key, so that keys do not share state such as the backing array of a slice.
+func AggregateBy[T any, K comparable, V any](it func(func(T) bool), keyFn func(T) K, init func() V, fn func(V, T) V) map[K]V {
+	m := make(map[K]V)
+	for t := range it {
+		k := keyFn(t)
+		acc, ok := m[k]
+		if !ok {
+			acc = init()
+		}
+		m[k] = fn(acc, t)
+	}
+	return m
+}
+
+// ReduceBy is like AggregateBy, but starts the reduction for each key from the first element with that key
+func ReduceBy[T any, K comparable](it func(func(T) bool), keyFn func(T) K, fn func(T, T) T) map[K]T {
+	m := make(map[K]T)
+	for t := range it {
+		k := keyFn(t)
+		if acc, ok := m[k]; ok {
+			m[k] = fn(acc, t)
+		} else {
+			m[k] = t
+		}
+	}
+	return m
+}
+
+// Aggregation is a reduction function along with a function returning its initial value
+type Aggregation[T, V any] struct {
+	Init func() V
+	Fn   func(V, T) V
+}
+
+// MultiAggregateBy computes several aggregations per key in a single pass over it. The values for each key are in the
+// same order as aggs.
+func MultiAggregateBy[T any, K comparable, V any](it func(func(T) bool), keyFn func(T) K, aggs ...Aggregation[T, V]) map[K][]V {
+	m := make(map[K][]V)
+	for t := range it {
+		k := keyFn(t)
+		accs, ok := m[k]
+		if !ok {
+			accs = make([]V, len(aggs))
+			for i, agg := range aggs {
+				accs[i] = agg.Init()
+			}
+			m[k] = accs
+		}
+		for i, agg := range aggs {
+			accs[i] = agg.Fn(accs[i], t)
+		}
+	}
+	return m
+}
+
 func TakeWhile[T any](it func(func(T) bool), cond func(T) bool) func(func(T) bool) {
 	return func(yield func(T) bool) {
 		for t := range it {
@@ -175,6 +290,34 @@ func Accumulate[T any](it func(func(T) bool), fn func(T, T) T) func(func(T) bool
 	}
 }
 
+// Scan returns an iterator that yields the state after applying fn to each element of it, starting from init. Unlike
+// Accumulate, the state may have a different type to the elements.
+func Scan[T, V any](it func(func(T) bool), init V, fn func(V, T) V) func(func(V) bool) {
+	return func(yield func(V) bool) {
+		acc := init
+		for t := range it {
+			acc = fn(acc, t)
+			if !yield(acc) {
+				break
+			}
+		}
+	}
+}
+
+// ScanMap is like Scan, but fn also returns an output value for each step, which is yielded instead of the state
+func ScanMap[T, S, V any](it func(func(T) bool), init S, fn func(S, T) (S, V)) func(func(V) bool) {
+	return func(yield func(V) bool) {
+		state := init
+		for t := range it {
+			var v V
+			state, v = fn(state, t)
+			if !yield(v) {
+				break
+			}
+		}
+	}
+}
+
 func Chunk[T any](it func(func(T) bool), size int) func(func([]T) bool) {
 	return func(yield func([]T) bool) {
 		batch := make([]T, size)
@@ -223,11 +366,32 @@ func Flatten[T any, S ~[]T](it func(func(S) bool)) func(func(T) bool) {
 	}
 }
 
+// FlatMap returns an iterator that yields the elements of the sequences returned by applying fn to each element of it
+func FlatMap[T, V any](it func(func(T) bool), fn func(T) func(func(V) bool)) func(func(V) bool) {
+	return FlattenSeq(Map(it, fn))
+}
+
+// FlattenSeq is like Flatten, but for an iterator of iterators
+func FlattenSeq[T any](it func(func(func(func(T) bool)) bool)) func(func(T) bool) {
+	return func(yield func(T) bool) {
+		for inner := range it {
+			for t := range inner {
+				if !yield(t) {
+					return
+				}
+			}
+		}
+	}
+}
+
 type teeState[T any] struct {
 	next      func() (T, bool)
+	stop      func()
 	mu        sync.Mutex
 	buf       []T
 	positions []int
+	finished  []bool
+	nFinished int
 }
 
 func (s *teeState[T]) advanceOne(i int) (T, bool) {
@@ -253,14 +417,31 @@ func (s *teeState[T]) advanceOne(i int) (T, bool) {
 	return s.buf[pos], true
 }
 
+// stopOne records that branch i has stopped early, stopping the underlying iterator once every branch has. Stopping
+// the same branch again has no effect.
+func (s *teeState[T]) stopOne(i int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.finished[i] {
+		return
+	}
+	s.finished[i] = true
+	s.nFinished++
+	if s.nFinished == len(s.finished) {
+		s.stop()
+	}
+}
+
 func Tee[T any](it func(func(T) bool), n int) []func(func(T) bool) {
 	next, stop := iter.Pull(it)
 
 	state := &teeState[T]{
 		next:      next,
+		stop:      stop,
 		positions: make([]int, n),
+		finished:  make([]bool, n),
 	}
-	stopped := 0
 
 	outs := make([]func(func(T) bool), n)
 	for i := range n {
@@ -272,13 +453,10 @@ func Tee[T any](it func(func(T) bool), n int) []func(func(T) bool) {
 					return
 				}
 				if !yield(t) {
-					stopped++
-					break
+					state.stopOne(i)
+					return
 				}
 			}
-			if stopped == n {
-				stop()
-			}
 		}
 	}
 	return outs
@@ -1,13 +1,18 @@
 package iterator
 
 import (
+	"go-exp/functions"
 	"go-exp/functions/partials"
+	"go-exp/functions/reducers"
+	"go-exp/pointer"
 	"gotest.tools/v3/assert"
 	"maps"
+	"math"
 	"slices"
 	"strconv"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"testing"
 )
 
@@ -78,6 +83,32 @@ func TestZipWith(t *testing.T) {
 	assert.DeepEqual(t, result, expected)
 }
 
+func TestZipLongest(t *testing.T) {
+	it1 := slices.Values([]int{1, 2, 3})
+	it2 := slices.Values([]string{"a"})
+
+	var ns []int
+	var ss []string
+	for n, s := range ZipLongest(it1, it2) {
+		ns = append(ns, pointer.GetOrElse(n, -1))
+		ss = append(ss, pointer.GetOrElse(s, "-"))
+	}
+	assert.DeepEqual(t, ns, []int{1, 2, 3})
+	assert.DeepEqual(t, ss, []string{"a", "-", "-"})
+}
+
+func TestZipN(t *testing.T) {
+	cols := []func(func(int) bool){
+		slices.Values([]int{1, 2, 3}),
+		slices.Values([]int{4, 5, 6, 7}),
+		slices.Values([]int{8, 9, 10}),
+	}
+
+	result := slices.Collect(ZipN(cols))
+	assert.DeepEqual(t, result, [][]int{{1, 4, 8}, {2, 5, 9}, {3, 6, 10}})
+	assert.Equal(t, len(slices.Collect(ZipN[int](nil))), 0)
+}
+
 func TestIndexed(t *testing.T) {
 	it := slices.Values([]string{"c", "b", "a"})
 	result := maps.Collect(Indexed(it))
@@ -123,6 +154,42 @@ func TestGroupBy(t *testing.T) {
 	assert.DeepEqual(t, result, map[bool][]int{false: {1, 3, 5, 7, 9}, true: {2, 4, 6, 8, 10}})
 }
 
+func TestAggregateBy(t *testing.T) {
+	it := slices.Values([]int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10})
+	isEven := func(n int) bool { return n%2 == 0 }
+
+	assert.DeepEqual(t, AggregateBy(it, isEven, functions.Zero[int], reducers.Add), map[bool]int{false: 25, true: 30})
+	assert.DeepEqual(t, AggregateBy(it, isEven, functions.Zero[[]int], reducers.Append), map[bool][]int{false: {1, 3, 5, 7, 9}, true: {2, 4, 6, 8, 10}})
+
+	// every key gets its own backing array, even when the initial slice has spare capacity
+	withCap := func() []int { return make([]int, 0, 8) }
+	assert.DeepEqual(t, AggregateBy(it, isEven, withCap, reducers.Append), map[bool][]int{false: {1, 3, 5, 7, 9}, true: {2, 4, 6, 8, 10}})
+}
+
+func TestReduceBy(t *testing.T) {
+	it := slices.Values([]int{-3, 5, -8, 2, 7, -1})
+	isNeg := func(n int) bool { return n < 0 }
+
+	assert.DeepEqual(t, ReduceBy(it, isNeg, reducers.Min), map[bool]int{false: 2, true: -8})
+	assert.DeepEqual(t, ReduceBy(it, isNeg, reducers.Max), map[bool]int{false: 7, true: -1})
+}
+
+func TestMultiAggregateBy(t *testing.T) {
+	it := slices.Values([]float64{1.5, 2, 3.5, 4, 5.5})
+	isWhole := func(x float64) bool { return x == math.Trunc(x) }
+
+	result := MultiAggregateBy(it, isWhole,
+		Aggregation[float64, float64]{functions.Zero[float64], reducers.Add[float64]},
+		Aggregation[float64, float64]{func() float64 { return math.Inf(1) }, reducers.Min[float64]},
+		Aggregation[float64, float64]{func() float64 { return math.Inf(-1) }, reducers.Max[float64]},
+	)
+	assert.DeepEqual(t, result, map[bool][]float64{false: {10.5, 1.5, 5.5}, true: {6, 2, 4}})
+
+	withCap := func() []float64 { return make([]float64, 0, 8) }
+	collected := MultiAggregateBy(it, isWhole, Aggregation[float64, []float64]{withCap, reducers.Append[[]float64]})
+	assert.DeepEqual(t, collected, map[bool][][]float64{false: {{1.5, 3.5, 5.5}}, true: {{2, 4}}})
+}
+
 func TestTakeWhile(t *testing.T) {
 	tests := []struct {
 		s    []int
@@ -152,6 +219,33 @@ func TestAccumulate(t *testing.T) {
 	assert.DeepEqual(t, result, expected)
 }
 
+func TestScan(t *testing.T) {
+	it := slices.Values([]int{1, 2, 3, 4})
+
+	assert.DeepEqual(t, slices.Collect(Scan(it, 0, reducers.Add)), []int{1, 3, 6, 10})
+	assert.DeepEqual(t, slices.Collect(Scan(it, []int(nil), reducers.Append)), [][]int{{1}, {1, 2}, {1, 2, 3}, {1, 2, 3, 4}})
+
+	lengths := slices.Collect(Scan(slices.Values([]string{"a", "bb", "ccc"}), 0, func(acc int, s string) int {
+		return acc + len(s)
+	}))
+	assert.DeepEqual(t, lengths, []int{1, 3, 6})
+}
+
+func TestScanMap(t *testing.T) {
+	type stats struct {
+		n   int
+		sum float64
+	}
+	it := slices.Values([]float64{2, 4, 6, 8})
+
+	means := slices.Collect(ScanMap(it, stats{}, func(s stats, x float64) (stats, float64) {
+		s.n++
+		s.sum += x
+		return s, s.sum / float64(s.n)
+	}))
+	assert.DeepEqual(t, means, []float64{2, 3, 4, 5})
+}
+
 func TestChunk(t *testing.T) {
 	nums := []int{1, 2, 3, 4, 5, 6, 7, 8, 9}
 
@@ -201,6 +295,25 @@ func TestFlatten(t *testing.T) {
 	assert.DeepEqual(t, result, expected)
 }
 
+func TestFlatMap(t *testing.T) {
+	it := slices.Values([]string{"a b", "", "c d e"})
+	result := slices.Collect(FlatMap(it, func(s string) func(func(string) bool) {
+		return slices.Values(strings.Fields(s))
+	}))
+
+	assert.DeepEqual(t, result, []string{"a", "b", "c", "d", "e"})
+}
+
+func TestFlattenSeq(t *testing.T) {
+	it := slices.Values([]func(func(int) bool){Range(0, 3, 1), Range(10, 12, 1)})
+	assert.DeepEqual(t, slices.Collect(FlattenSeq(it)), []int{0, 1, 2, 10, 11})
+
+	// inner iterators may be infinite
+	first, ok := First(FlattenSeq(slices.Values([]func(func(int) bool){Repeat(7)})))
+	assert.Assert(t, ok)
+	assert.Equal(t, first, 7)
+}
+
 func TestTee(t *testing.T) {
 	it := slices.Values([]int{4, 3, 2, 1})
 	its := Tee(it, 2)
@@ -213,6 +326,45 @@ func TestTee(t *testing.T) {
 	assert.DeepEqual(t, result2, expected)
 }
 
+func TestTee_BreakTwice(t *testing.T) {
+	its := Tee(slices.Values([]int{1, 2, 3, 4}), 2)
+
+	for range 2 {
+		for range its[0] {
+			break
+		}
+	}
+	assert.DeepEqual(t, slices.Collect(its[1]), []int{1, 2, 3, 4})
+}
+
+func TestTee_ConcurrentBreaks(t *testing.T) {
+	var stopped atomic.Bool
+	it := func(yield func(int) bool) {
+		defer stopped.Store(true)
+		for i := 0; ; i++ {
+			if !yield(i) {
+				return
+			}
+		}
+	}
+	its := Tee(it, 8)
+
+	var wg sync.WaitGroup
+	for i := range its {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for n := range its[i] {
+				if n == 10*i {
+					break
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	assert.Assert(t, stopped.Load())
+}
+
 func BenchmarkTee(b *testing.B) {
 	if b.N > 10000000 {
 		b.Skipf("N too large: %d", b.N)
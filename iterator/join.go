@@ -0,0 +1,115 @@
+package iterator
+
+// JoinMode controls which unmatched elements a join yields. Unmatched elements are paired with nil.
+type JoinMode int
+
+const (
+	// InnerJoin yields only matched pairs
+	InnerJoin JoinMode = iota
+	// LeftOuterJoin also yields left elements without a match
+	LeftOuterJoin
+	// FullOuterJoin also yields left and right elements without a match
+	FullOuterJoin
+)
+
+// HashJoin returns an iterator over the pairs of elements from left and right with equal keys. right is read into a
+// hash table when iteration starts and left is streamed, so pairs are yielded in left order, followed by unmatched
+// right elements in right order under FullOuterJoin.
+func HashJoin[L, R any, K comparable](left func(func(L) bool), right func(func(R) bool), leftKey func(L) K, rightKey func(R) K, mode JoinMode) func(func(*L, *R) bool) {
+	return func(yield func(*L, *R) bool) {
+		var rights []R
+		table := make(map[K][]int)
+		for r := range right {
+			k := rightKey(r)
+			table[k] = append(table[k], len(rights))
+			rights = append(rights, r)
+		}
+
+		var matched []bool
+		if mode == FullOuterJoin {
+			matched = make([]bool, len(rights))
+		}
+		for l := range left {
+			indices, ok := table[leftKey(l)]
+			if !ok {
+				if mode != InnerJoin && !yield(&l, nil) {
+					return
+				}
+				continue
+			}
+			for _, i := range indices {
+				if matched != nil {
+					matched[i] = true
+				}
+				l, r := l, rights[i]
+				if !yield(&l, &r) {
+					return
+				}
+			}
+		}
+
+		for i, ok := range matched {
+			if r := rights[i]; !ok && !yield(nil, &r) {
+				return
+			}
+		}
+	}
+}
+
+// MergeJoin is like HashJoin, but for inputs that are sorted by key according to cmp. It reads both inputs in a
+// single pass, holding only the right elements for the current key in memory, and yields pairs in key order.
+func MergeJoin[L, R, K any](left func(func(L) bool), right func(func(R) bool), leftKey func(L) K, rightKey func(R) K, cmp func(a, b K) int, mode JoinMode) func(func(*L, *R) bool) {
+	return func(yield func(*L, *R) bool) {
+		lp := NewPeekable(left)
+		defer lp.Close()
+		rp := NewPeekable(right)
+		defer rp.Close()
+
+		var group []R
+		for {
+			l, lok := lp.Peek()
+			r, rok := rp.Peek()
+			if !lok && !rok {
+				return
+			}
+
+			c := 0
+			if !rok {
+				c = -1
+			} else if !lok {
+				c = 1
+			} else {
+				c = cmp(leftKey(l), rightKey(r))
+			}
+
+			switch {
+			case c < 0:
+				lp.Skip(1)
+				if mode != InnerJoin && !yield(&l, nil) {
+					return
+				}
+			case c > 0:
+				rp.Skip(1)
+				if mode == FullOuterJoin && !yield(nil, &r) {
+					return
+				}
+			default:
+				k := rightKey(r)
+				group = group[:0]
+				for r, ok := rp.Peek(); ok && cmp(rightKey(r), k) == 0; r, ok = rp.Peek() {
+					group = append(group, r)
+					rp.Skip(1)
+				}
+				for l, ok := lp.Peek(); ok && cmp(leftKey(l), k) == 0; l, ok = lp.Peek() {
+					lp.Skip(1)
+					for _, r := range group {
+						l, r := l, r
+						if !yield(&l, &r) {
+							return
+						}
+					}
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,98 @@
+package iterator
+
+import (
+	"cmp"
+	"go-exp/functions"
+	"gotest.tools/v3/assert"
+	"slices"
+	"testing"
+)
+
+type user struct {
+	ID   int
+	Name string
+}
+
+type order struct {
+	UserID int
+	Item   string
+}
+
+func joinStrings(it func(func(*user, *order) bool)) []string {
+	var result []string
+	for u, o := range it {
+		s := "-"
+		if u != nil {
+			s = u.Name
+		}
+		if o != nil {
+			s += ":" + o.Item
+		} else {
+			s += ":-"
+		}
+		result = append(result, s)
+	}
+	return result
+}
+
+var (
+	users  = []user{{1, "ann"}, {2, "bob"}, {3, "cat"}}
+	orders = []order{{1, "pen"}, {3, "cup"}, {1, "ink"}, {4, "hat"}}
+)
+
+func TestHashJoin(t *testing.T) {
+	userID := func(u user) int { return u.ID }
+	orderUserID := func(o order) int { return o.UserID }
+
+	tests := []struct {
+		mode JoinMode
+		want []string
+	}{
+		{InnerJoin, []string{"ann:pen", "ann:ink", "cat:cup"}},
+		{LeftOuterJoin, []string{"ann:pen", "ann:ink", "bob:-", "cat:cup"}},
+		{FullOuterJoin, []string{"ann:pen", "ann:ink", "bob:-", "cat:cup", "-:hat"}},
+	}
+
+	for _, tc := range tests {
+		it := HashJoin(slices.Values(users), slices.Values(orders), userID, orderUserID, tc.mode)
+		assert.DeepEqual(t, joinStrings(it), tc.want)
+	}
+}
+
+func TestMergeJoin(t *testing.T) {
+	userID := func(u user) int { return u.ID }
+	orderUserID := func(o order) int { return o.UserID }
+	sorted := slices.Clone(orders)
+	slices.SortStableFunc(sorted, func(a, b order) int { return a.UserID - b.UserID })
+	sortedUsers := append(slices.Clone(users), user{1, "amy"})
+	slices.SortStableFunc(sortedUsers, func(a, b user) int { return a.ID - b.ID })
+
+	tests := []struct {
+		mode JoinMode
+		want []string
+	}{
+		{InnerJoin, []string{"ann:pen", "ann:ink", "amy:pen", "amy:ink", "cat:cup"}},
+		{LeftOuterJoin, []string{"ann:pen", "ann:ink", "amy:pen", "amy:ink", "bob:-", "cat:cup"}},
+		{FullOuterJoin, []string{"ann:pen", "ann:ink", "amy:pen", "amy:ink", "bob:-", "cat:cup", "-:hat"}},
+	}
+
+	for _, tc := range tests {
+		it := MergeJoin(slices.Values(sortedUsers), slices.Values(sorted), userID, orderUserID, cmp.Compare[int], tc.mode)
+		assert.DeepEqual(t, joinStrings(it), tc.want)
+	}
+}
+
+func TestMergeJoin_EarlyTermination(t *testing.T) {
+	evens := Generate(0, func(n int) int { return n + 2 })
+	threes := Generate(0, func(n int) int { return n + 3 })
+
+	var result []int
+	for l, r := range MergeJoin(evens, threes, functions.Identity[int], functions.Identity[int], cmp.Compare[int], InnerJoin) {
+		assert.Equal(t, *l, *r)
+		result = append(result, *l)
+		if len(result) == 3 {
+			break
+		}
+	}
+	assert.DeepEqual(t, result, []int{0, 6, 12})
+}
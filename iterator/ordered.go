@@ -1,35 +1,47 @@
 package iterator
 
 import (
+	"cmp"
 	"container/heap"
 	"golang.org/x/exp/constraints"
 	"iter"
 )
 
-type indexedItem[T constraints.Ordered] struct {
+type indexedItem[T any] struct {
 	v T
 	i int
 }
 
-type indexedHeap[T constraints.Ordered] []indexedItem[T]
+type indexedHeap[T any] struct {
+	items []indexedItem[T]
+	cmp   func(T, T) int
+	// stable breaks ties between equal items by their source index
+	stable bool
+}
 
 // sort
 
-func (h indexedHeap[T]) Len() int           { return len(h) }
-func (h indexedHeap[T]) Less(i, j int) bool { return h[i].v < h[j].v }
-func (h indexedHeap[T]) Swap(i, j int)      { h[i], h[j] = h[j], h[i] }
+func (h *indexedHeap[T]) Len() int { return len(h.items) }
+func (h *indexedHeap[T]) Less(i, j int) bool {
+	c := h.cmp(h.items[i].v, h.items[j].v)
+	if c == 0 && h.stable {
+		return h.items[i].i < h.items[j].i
+	}
+	return c < 0
+}
+func (h *indexedHeap[T]) Swap(i, j int) { h.items[i], h.items[j] = h.items[j], h.items[i] }
 
 // heap
 
 func (h *indexedHeap[T]) Push(x any) {
-	*h = append(*h, x.(indexedItem[T]))
+	h.items = append(h.items, x.(indexedItem[T]))
 }
 
 func (h *indexedHeap[T]) Pop() any {
-	old := *h
+	old := h.items
 	n := len(old)
 	x := old[n-1]
-	*h = old[0 : n-1]
+	h.items = old[0 : n-1]
 	return x
 }
 
@@ -38,16 +50,32 @@ type pullIterator[T any] struct {
 	stop func()
 }
 
-func MergeOrdered[T constraints.Ordered](its ...func(func(T) bool)) func(func(T) bool) {
+func pullAll[T any](its []func(func(T) bool)) []pullIterator[T] {
 	pulls := make([]pullIterator[T], len(its))
 	for i, it := range its {
 		next, stop := iter.Pull(it)
 		pulls[i] = pullIterator[T]{next, stop}
 	}
-	return mergeOrderedPulls(pulls)
+	return pulls
+}
+
+func MergeOrdered[T constraints.Ordered](its ...func(func(T) bool)) func(func(T) bool) {
+	return MergeOrderedFunc(cmp.Compare[T], its...)
+}
+
+// MergeOrderedFunc merges iterators that are sorted according to cmp into a single sorted iterator. The order of
+// equal elements from different iterators is unspecified.
+func MergeOrderedFunc[T any](cmp func(a, b T) int, its ...func(func(T) bool)) func(func(T) bool) {
+	return mergeOrderedPulls(pullAll(its), cmp, false)
+}
+
+// MergeOrderedStableFunc is like MergeOrderedFunc, but yields equal elements in the order of the iterators they came
+// from
+func MergeOrderedStableFunc[T any](cmp func(a, b T) int, its ...func(func(T) bool)) func(func(T) bool) {
+	return mergeOrderedPulls(pullAll(its), cmp, true)
 }
 
-func mergeOrderedPulls[T constraints.Ordered](its []pullIterator[T]) func(func(T) bool) {
+func mergeOrderedPulls[T any](its []pullIterator[T], cmp func(T, T) int, stable bool) func(func(T) bool) {
 	return func(yield func(T) bool) {
 		defer func() {
 			for _, it := range its {
@@ -56,7 +84,7 @@ func mergeOrderedPulls[T constraints.Ordered](its []pullIterator[T]) func(func(T
 		}()
 
 		nOpen := len(its)
-		h := &indexedHeap[T]{}
+		h := &indexedHeap[T]{cmp: cmp, stable: stable}
 		for i := 0; i < len(its); i++ {
 			v, ok := its[i].next()
 			if ok {
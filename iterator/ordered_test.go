@@ -15,3 +15,30 @@ func Test_MergeOrdered(t *testing.T) {
 	s := slices.Collect(MergeOrdered(odds, evens, threes))
 	assert.DeepEqual(t, s, []int{0, 1, 2, 3, 3, 4, 5, 6, 6, 7, 8, 9, 9})
 }
+
+type record struct {
+	Ts  int
+	Src string
+}
+
+func compareTs(a, b record) int {
+	return a.Ts - b.Ts
+}
+
+func Test_MergeOrderedFunc(t *testing.T) {
+	a := slices.Values([]record{{1, "a"}, {4, "a"}, {7, "a"}})
+	b := slices.Values([]record{{2, "b"}, {3, "b"}, {8, "b"}})
+
+	s := slices.Collect(Map(MergeOrderedFunc(compareTs, a, b), func(r record) int { return r.Ts }))
+	assert.DeepEqual(t, s, []int{1, 2, 3, 4, 7, 8})
+}
+
+func Test_MergeOrderedStableFunc(t *testing.T) {
+	a := slices.Values([]record{{1, "a"}, {2, "a"}, {2, "a"}})
+	b := slices.Values([]record{{1, "b"}, {2, "b"}})
+	c := slices.Values([]record{{0, "c"}, {1, "c"}, {2, "c"}})
+
+	s := slices.Collect(MergeOrderedStableFunc(compareTs, a, b, c))
+	expected := []record{{0, "c"}, {1, "a"}, {1, "b"}, {1, "c"}, {2, "a"}, {2, "a"}, {2, "b"}, {2, "c"}}
+	assert.DeepEqual(t, s, expected)
+}
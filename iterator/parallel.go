@@ -0,0 +1,158 @@
+package iterator
+
+import "sync"
+
+type parallelJob[T, V any] struct {
+	t      T
+	result chan V
+}
+
+// ParallelMap returns an iterator that applies fn to the elements of it on a pool of workers goroutines, yielding the
+// results in input order. At most workers+2 elements have been read from it but not yet yielded at once: one whose
+// result is being waited for, workers more queued behind it, and one waiting to be queued. When the consumer stops
+// early, the workers are stopped before the iterator returns. it is ranged on a goroutine of its own that is not waited
+// for, and that stops it the next time it yields, so a source that blocks does not keep the iterator from returning.
+func ParallelMap[T, V any](it func(func(T) bool), workers int, fn func(T) V) func(func(V) bool) {
+	if workers <= 0 {
+		panic("iterator: workers must be positive")
+	}
+	return func(yield func(V) bool) {
+		done := make(chan struct{})
+		jobs := make(chan parallelJob[T, V])
+		pending := make(chan chan V, workers)
+
+		// only the workers are waited for, as the feeder may be blocked in it
+		var wg sync.WaitGroup
+		defer wg.Wait()
+		defer close(done)
+
+		go func() {
+			defer close(jobs)
+			defer close(pending)
+			for t := range it {
+				job := parallelJob[T, V]{t, make(chan V, 1)}
+				select {
+				case pending <- job.result:
+				case <-done:
+					return
+				}
+				select {
+				case jobs <- job:
+				case <-done:
+					return
+				}
+			}
+		}()
+
+		for range workers {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for {
+					select {
+					case job, ok := <-jobs:
+						if !ok {
+							return
+						}
+						job.result <- fn(job.t)
+					case <-done:
+						return
+					}
+				}
+			}()
+		}
+
+		for result := range pending {
+			if !yield(<-result) {
+				return
+			}
+		}
+	}
+}
+
+// ParallelMapUnordered is like ParallelMap, but yields results as soon as they are available rather than in input
+// order. At most workers+1 elements have been read from it but not yet yielded at once.
+func ParallelMapUnordered[T, V any](it func(func(T) bool), workers int, fn func(T) V) func(func(V) bool) {
+	return parallelUnordered(it, workers, func(t T, emit func(V) bool) bool {
+		return emit(fn(t))
+	})
+}
+
+// FlatMapParallel is like FlatMap, but runs fn and iterates the resulting sequences on a pool of workers goroutines.
+// The elements of up to workers inner sequences are interleaved as they become available.
+func FlatMapParallel[T, V any](it func(func(T) bool), workers int, fn func(T) func(func(V) bool)) func(func(V) bool) {
+	return parallelUnordered(it, workers, func(t T, emit func(V) bool) bool {
+		for v := range fn(t) {
+			if !emit(v) {
+				return false
+			}
+		}
+		return true
+	})
+}
+
+// parallelUnordered runs work on each element of it on a pool of workers goroutines, yielding the values that work
+// emits as soon as they are available. emit returns false once the consumer has stopped, after which work should
+// return false so that its worker stops taking jobs.
+func parallelUnordered[T, V any](it func(func(T) bool), workers int, work func(t T, emit func(V) bool) bool) func(func(V) bool) {
+	if workers <= 0 {
+		panic("iterator: workers must be positive")
+	}
+	return func(yield func(V) bool) {
+		done := make(chan struct{})
+		jobs := make(chan T)
+		results := make(chan V)
+
+		// only the workers are waited for, as the feeder may be blocked in it
+		var wg sync.WaitGroup
+		defer wg.Wait()
+		defer close(done)
+
+		go func() {
+			defer close(jobs)
+			for t := range it {
+				select {
+				case jobs <- t:
+				case <-done:
+					return
+				}
+			}
+		}()
+
+		emit := func(v V) bool {
+			select {
+			case results <- v:
+				return true
+			case <-done:
+				return false
+			}
+		}
+
+		for range workers {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for {
+					select {
+					case t, ok := <-jobs:
+						if !ok || !work(t, emit) {
+							return
+						}
+					case <-done:
+						return
+					}
+				}
+			}()
+		}
+		go func() {
+			wg.Wait()
+			close(results)
+		}()
+
+		for v := range results {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,150 @@
+package iterator
+
+import (
+	"gotest.tools/v3/assert"
+	"slices"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestParallelMap(t *testing.T) {
+	it := slices.Values([]int{5, 1, 4, 2, 3, 0})
+	result := slices.Collect(ParallelMap(it, 3, func(n int) int {
+		time.Sleep(time.Duration(n) * time.Millisecond)
+		return n * n
+	}))
+
+	assert.DeepEqual(t, result, []int{25, 1, 16, 4, 9, 0})
+}
+
+func TestParallelMap_EarlyTermination(t *testing.T) {
+	var read, running atomic.Int32
+	it := Map(Generate(0, func(x int) int { return x + 1 }), func(n int) int {
+		read.Add(1)
+		return n
+	})
+
+	result := slices.Collect(TakeWhile(ParallelMap(it, 4, func(n int) int {
+		running.Add(1)
+		defer running.Add(-1)
+		return n
+	}), func(n int) bool { return n < 10 }))
+
+	assert.DeepEqual(t, result, []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9})
+	assert.Assert(t, read.Load() <= 10+4+2)
+	assert.Equal(t, running.Load(), int32(0))
+}
+
+// blockingSource returns an iterator that yields 1 and then blocks until release is called
+func blockingSource() (it func(func(int) bool), release func()) {
+	ch := make(chan int, 1)
+	ch <- 1
+	return func(yield func(int) bool) {
+		for n := range ch {
+			if !yield(n) {
+				return
+			}
+		}
+	}, func() { close(ch) }
+}
+
+// assertReturns fails the test if ranging over it and stopping after the first element doesn't return promptly
+func assertReturns[T any](t *testing.T, it func(func(T) bool)) {
+	returned := make(chan struct{})
+	go func() {
+		defer close(returned)
+		for range it {
+			break
+		}
+	}()
+	select {
+	case <-returned:
+	case <-time.After(time.Second):
+		t.Fatal("iterator did not return after the consumer stopped")
+	}
+}
+
+func TestParallelMap_BlockingSource(t *testing.T) {
+	it, release := blockingSource()
+	defer release()
+	assertReturns(t, ParallelMap(it, 2, func(n int) int { return n }))
+}
+
+func TestParallelMapUnordered(t *testing.T) {
+	it := slices.Values([]int{30, 1, 2, 3})
+	result := slices.Collect(ParallelMapUnordered(it, 2, func(n int) int {
+		time.Sleep(time.Duration(n) * time.Millisecond)
+		return n
+	}))
+
+	assert.Equal(t, result[len(result)-1], 30)
+	slices.Sort(result)
+	assert.DeepEqual(t, result, []int{1, 2, 3, 30})
+}
+
+func TestParallelMapUnordered_EarlyTermination(t *testing.T) {
+	var calls, running atomic.Int32
+	it := ParallelMapUnordered(Generate(0, func(x int) int { return x + 1 }), 4, func(n int) int {
+		calls.Add(1)
+		running.Add(1)
+		defer running.Add(-1)
+		return n
+	})
+
+	n := 0
+	for range it {
+		n++
+		if n == 10 {
+			break
+		}
+	}
+	assert.Assert(t, calls.Load() <= 10+4+1)
+	assert.Equal(t, running.Load(), int32(0))
+}
+
+func TestParallelMapUnordered_BlockingSource(t *testing.T) {
+	it, release := blockingSource()
+	defer release()
+	assertReturns(t, ParallelMapUnordered(it, 2, func(n int) int { return n }))
+}
+
+func TestFlatMapParallel(t *testing.T) {
+	it := slices.Values([]int{1, 2, 3, 4})
+	result := slices.Collect(FlatMapParallel(it, 2, func(n int) func(func(int) bool) {
+		return RepeatN(n, n)
+	}))
+
+	slices.Sort(result)
+	assert.DeepEqual(t, result, []int{1, 2, 2, 3, 3, 3, 4, 4, 4, 4})
+}
+
+func TestFlatMapParallel_BlockingSource(t *testing.T) {
+	it, release := blockingSource()
+	defer release()
+	assertReturns(t, FlatMapParallel(it, 2, func(n int) func(func(int) bool) { return RepeatN(n, 3) }))
+}
+
+func TestFlatMapParallel_EarlyTermination(t *testing.T) {
+	var active atomic.Int32
+	it := FlatMapParallel(Generate(0, func(n int) int { return n + 1 }), 3, func(n int) func(func(int) bool) {
+		return func(yield func(int) bool) {
+			active.Add(1)
+			defer active.Add(-1)
+			for v := range Repeat(n) {
+				if !yield(v) {
+					return
+				}
+			}
+		}
+	})
+
+	n := 0
+	for range it {
+		n++
+		if n == 100 {
+			break
+		}
+	}
+	assert.Equal(t, active.Load(), int32(0))
+}
@@ -0,0 +1,97 @@
+package iterator
+
+import (
+	"iter"
+	"slices"
+)
+
+// Peekable is a pull iterator with lookahead and pushback. Close must be called once it is no longer needed, unless it
+// has been exhausted.
+type Peekable[T any] struct {
+	next func() (T, bool)
+	stop func()
+	// buf holds elements that have been pulled or unread but not yet returned by Next, in order
+	buf []T
+}
+
+func NewPeekable[T any](it func(func(T) bool)) *Peekable[T] {
+	next, stop := iter.Pull(it)
+	return &Peekable[T]{next: next, stop: stop}
+}
+
+// fill pulls elements until at least n are buffered, returning false if the iterator is exhausted first
+func (p *Peekable[T]) fill(n int) bool {
+	for len(p.buf) < n {
+		t, ok := p.next()
+		if !ok {
+			return false
+		}
+		p.buf = append(p.buf, t)
+	}
+	return true
+}
+
+func (p *Peekable[T]) Next() (T, bool) {
+	if !p.fill(1) {
+		var zero T
+		return zero, false
+	}
+	t := p.buf[0]
+	p.buf = p.buf[1:]
+	return t, true
+}
+
+// Peek returns the next element without consuming it
+func (p *Peekable[T]) Peek() (T, bool) {
+	if !p.fill(1) {
+		var zero T
+		return zero, false
+	}
+	return p.buf[0], true
+}
+
+// PeekN returns up to n next elements without consuming them. Fewer are returned if the iterator is exhausted first,
+// and nil if n <= 0.
+func (p *Peekable[T]) PeekN(n int) []T {
+	if n <= 0 {
+		return nil
+	}
+	p.fill(n)
+	return slices.Clone(p.buf[:min(n, len(p.buf))])
+}
+
+// Unread pushes t back, so that it is the next element returned
+func (p *Peekable[T]) Unread(t T) {
+	p.buf = slices.Insert(p.buf, 0, t)
+}
+
+// Skip consumes up to n elements and returns the number consumed, which is 0 if n <= 0
+func (p *Peekable[T]) Skip(n int) int {
+	if n <= 0 {
+		return 0
+	}
+	for i := range n {
+		if _, ok := p.Next(); !ok {
+			return i
+		}
+	}
+	return n
+}
+
+func (p *Peekable[T]) Close() {
+	p.buf = nil
+	p.stop()
+}
+
+// All returns an iterator over the remaining elements. Every yielded element is consumed, including the one at which
+// the consumer stops early, so that a later call continues after it, like a for loop over Next would.
+func (p *Peekable[T]) All() func(func(T) bool) {
+	return func(yield func(T) bool) {
+		for {
+			t, ok := p.Next()
+			if !ok || !yield(t) {
+				return
+			}
+		}
+	}
+}
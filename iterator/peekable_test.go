@@ -0,0 +1,80 @@
+package iterator
+
+import (
+	"gotest.tools/v3/assert"
+	"slices"
+	"testing"
+)
+
+func TestPeekable(t *testing.T) {
+	p := NewPeekable(slices.Values([]int{1, 2, 3, 4, 5, 6}))
+	defer p.Close()
+
+	v, ok := p.Peek()
+	assert.Assert(t, ok)
+	assert.Equal(t, v, 1)
+
+	v, ok = p.Next()
+	assert.Assert(t, ok)
+	assert.Equal(t, v, 1)
+
+	assert.DeepEqual(t, p.PeekN(3), []int{2, 3, 4})
+	p.Unread(10)
+	assert.DeepEqual(t, p.PeekN(2), []int{10, 2})
+
+	assert.Equal(t, p.Skip(2), 2)
+	v, _ = p.Next()
+	assert.Equal(t, v, 3)
+
+	assert.DeepEqual(t, p.PeekN(10), []int{4, 5, 6})
+	assert.Equal(t, p.Skip(10), 3)
+	_, ok = p.Next()
+	assert.Assert(t, !ok)
+	_, ok = p.Peek()
+	assert.Assert(t, !ok)
+}
+
+func TestPeekable_NonPositive(t *testing.T) {
+	p := NewPeekable(slices.Values([]int{1, 2}))
+	defer p.Close()
+
+	assert.Assert(t, p.PeekN(0) == nil)
+	assert.Assert(t, p.PeekN(-1) == nil)
+	assert.Equal(t, p.Skip(0), 0)
+	assert.Equal(t, p.Skip(-1), 0)
+	assert.DeepEqual(t, slices.Collect(p.All()), []int{1, 2})
+}
+
+func TestPeekable_All(t *testing.T) {
+	p := NewPeekable(slices.Values([]int{1, 2, 3, 4, 5}))
+	defer p.Close()
+
+	p.Skip(1)
+	// TakeWhile consumes the 4 that stops it
+	assert.DeepEqual(t, slices.Collect(TakeWhile(p.All(), func(n int) bool { return n < 4 })), []int{2, 3})
+	assert.DeepEqual(t, slices.Collect(p.All()), []int{5})
+}
+
+func TestPeekable_AllBreak(t *testing.T) {
+	p := NewPeekable(slices.Values([]int{1, 2, 3, 4, 5}))
+	defer p.Close()
+
+	var first []int
+	for n := range p.All() {
+		first = append(first, n)
+		if n == 2 {
+			break
+		}
+	}
+	assert.DeepEqual(t, first, []int{1, 2})
+	assert.DeepEqual(t, slices.Collect(p.All()), []int{3, 4, 5})
+}
+
+func TestPeekable_Close(t *testing.T) {
+	p := NewPeekable(Generate(0, func(n int) int { return n + 1 }))
+	p.Skip(5)
+	p.Close()
+
+	_, ok := p.Next()
+	assert.Assert(t, !ok)
+}
@@ -0,0 +1,148 @@
+package iterator
+
+import "slices"
+
+type stageKind uint8
+
+const (
+	mapStage stageKind = iota
+	filterStage
+	takeWhileStage
+)
+
+type stage[T any] struct {
+	kind  stageKind
+	mapFn func(T) T
+	pred  func(T) bool
+}
+
+// Pipeline records Map, Filter and TakeWhile stages over an iterator and compiles them into a chain of closures, each
+// running several stages at once, so an element makes far fewer calls than it would through nested combinators.
+// Pipelines are immutable; every stage method returns a new Pipeline.
+type Pipeline[T any] struct {
+	src    func(func(T) bool)
+	stages []stage[T]
+}
+
+func NewPipeline[T any](it func(func(T) bool)) *Pipeline[T] {
+	return &Pipeline[T]{src: it}
+}
+
+func (p *Pipeline[T]) with(s stage[T]) *Pipeline[T] {
+	return &Pipeline[T]{src: p.src, stages: append(slices.Clip(p.stages), s)}
+}
+
+func (p *Pipeline[T]) Map(fn func(T) T) *Pipeline[T] {
+	return p.with(stage[T]{kind: mapStage, mapFn: fn})
+}
+
+func (p *Pipeline[T]) Filter(fn func(T) bool) *Pipeline[T] {
+	return p.with(stage[T]{kind: filterStage, pred: fn})
+}
+
+func (p *Pipeline[T]) TakeWhile(fn func(T) bool) *Pipeline[T] {
+	return p.with(stage[T]{kind: takeWhileStage, pred: fn})
+}
+
+// All returns an iterator that compiles the pipeline each time it is iterated
+func (p *Pipeline[T]) All() func(func(T) bool) {
+	return func(yield func(T) bool) {
+		p.src(compileStages(p.stages, yield))
+	}
+}
+
+// MapPipeline adds a stage that changes the element type. Like the other stages, it is compiled into the chain of
+// closures, where it costs one extra call per element.
+func MapPipeline[T, V any](p *Pipeline[T], fn func(T) V) *Pipeline[V] {
+	return NewPipeline(func(yield func(V) bool) {
+		p.src(compileStages(p.stages, func(t T) bool {
+			return yield(fn(t))
+		}))
+	})
+}
+
+// maxSegmentMaps is the number of consecutive maps that compileStages runs in a single closure
+const maxSegmentMaps = 3
+
+// compileStages returns the closure that the input of stages is sent to, which sends the output of the last stage to
+// out. The stages are grouped into segments of up to maxSegmentMaps maps followed by at most one filter or take-while,
+// and each segment becomes a single closure specialised to its shape that calls the next one directly.
+func compileStages[T any](stages []stage[T], out func(T) bool) func(T) bool {
+	next := out
+	for end := len(stages); end > 0; {
+		start := end
+		var pred func(T) bool
+		var miss bool
+		if s := stages[end-1]; s.kind != mapStage {
+			// a filter skips the element and carries on, while a take-while stops the source
+			pred, miss = s.pred, s.kind == filterStage
+			start--
+		}
+		mapsEnd := start
+		for start > 0 && stages[start-1].kind == mapStage && mapsEnd-start < maxSegmentMaps {
+			start--
+		}
+
+		next = segment(stages[start:mapsEnd], pred, miss, next)
+		end = start
+	}
+	return next
+}
+
+// segment returns a closure that applies maps to an element, then sends it to next if pred is nil or returns true for
+// it, and otherwise returns miss
+func segment[T any](maps []stage[T], pred func(T) bool, miss bool, next func(T) bool) func(T) bool {
+	if pred == nil {
+		switch len(maps) {
+		case 1:
+			m := maps[0].mapFn
+			return func(t T) bool {
+				return next(m(t))
+			}
+		case 2:
+			m0, m1 := maps[0].mapFn, maps[1].mapFn
+			return func(t T) bool {
+				return next(m1(m0(t)))
+			}
+		default:
+			m0, m1, m2 := maps[0].mapFn, maps[1].mapFn, maps[2].mapFn
+			return func(t T) bool {
+				return next(m2(m1(m0(t))))
+			}
+		}
+	}
+
+	switch len(maps) {
+	case 0:
+		return func(t T) bool {
+			if !pred(t) {
+				return miss
+			}
+			return next(t)
+		}
+	case 1:
+		m := maps[0].mapFn
+		return func(t T) bool {
+			if t = m(t); !pred(t) {
+				return miss
+			}
+			return next(t)
+		}
+	case 2:
+		m0, m1 := maps[0].mapFn, maps[1].mapFn
+		return func(t T) bool {
+			if t = m1(m0(t)); !pred(t) {
+				return miss
+			}
+			return next(t)
+		}
+	default:
+		m0, m1, m2 := maps[0].mapFn, maps[1].mapFn, maps[2].mapFn
+		return func(t T) bool {
+			if t = m2(m1(m0(t))); !pred(t) {
+				return miss
+			}
+			return next(t)
+		}
+	}
+}
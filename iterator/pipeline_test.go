@@ -0,0 +1,128 @@
+package iterator
+
+import (
+	"gotest.tools/v3/assert"
+	"slices"
+	"strconv"
+	"testing"
+)
+
+func TestPipeline(t *testing.T) {
+	p := NewPipeline(Range(0, 100, 1)).
+		Map(func(n int) int { return n * 3 }).
+		Filter(func(n int) bool { return n%2 == 0 }).
+		TakeWhile(func(n int) bool { return n < 30 })
+
+	assert.DeepEqual(t, slices.Collect(p.All()), []int{0, 6, 12, 18, 24})
+
+	// stages don't leak between pipelines built from the same prefix
+	p1 := p.Map(func(n int) int { return n + 1 })
+	p2 := p.Map(func(n int) int { return n - 1 })
+	assert.DeepEqual(t, slices.Collect(p1.All()), []int{1, 7, 13, 19, 25})
+	assert.DeepEqual(t, slices.Collect(p2.All()), []int{-1, 5, 11, 17, 23})
+
+	first, ok := First(p.All())
+	assert.Assert(t, ok)
+	assert.Equal(t, first, 0)
+}
+
+func TestPipeline_Shapes(t *testing.T) {
+	isOdd := func(n int) bool { return n%2 != 0 }
+	below := func(limit int) func(int) bool { return func(n int) bool { return n < limit } }
+
+	// runs of maps longer than a segment, and predicates with no maps between them
+	p := NewPipeline(Range(0, 100, 1)).
+		Filter(isOdd).Filter(notDivBy3).
+		Map(incr).Map(incr).Map(incr).Map(incr).Map(double).
+		TakeWhile(below(150)).Map(incr).Filter(notDivBy5)
+	it := Filter(Map(TakeWhile(Map(Map(Map(Map(Map(Filter(Filter(Range(0, 100, 1), isOdd), notDivBy3),
+		incr), incr), incr), incr), double), below(150)), incr), notDivBy5)
+
+	assert.DeepEqual(t, slices.Collect(p.All()), slices.Collect(it))
+	assert.DeepEqual(t, slices.Collect(NewPipeline(slices.Values([]int{1})).All()), []int{1})
+}
+
+func TestPipeline_Nested(t *testing.T) {
+	it := NewPipeline(slices.Values([]int{1, 2, 3})).Map(double).All()
+
+	var pairs [][2]int
+	for a := range it {
+		for b := range it {
+			pairs = append(pairs, [2]int{a, b})
+		}
+		if a == 4 {
+			break
+		}
+	}
+	assert.DeepEqual(t, pairs, [][2]int{{2, 2}, {2, 4}, {2, 6}, {4, 2}, {4, 4}, {4, 6}})
+
+	// the iterator is still usable after the loops over it stop early
+	assert.DeepEqual(t, slices.Collect(it), []int{2, 4, 6})
+}
+
+func TestMapPipeline(t *testing.T) {
+	p := NewPipeline(slices.Values([]int{1, 2, 3, 4})).Filter(func(n int) bool { return n != 2 })
+	s := MapPipeline(p, strconv.Itoa).Map(func(s string) string { return s + s })
+
+	assert.DeepEqual(t, slices.Collect(s.All()), []string{"11", "33", "44"})
+	assert.DeepEqual(t, slices.Collect(MapPipeline(NewPipeline(slices.Values([]int{1, 2})), strconv.Itoa).All()),
+		[]string{"1", "2"})
+}
+
+const benchmarkSize = 1 << 16
+
+func benchmarkSource(yield func(int) bool) {
+	for i := range benchmarkSize {
+		if !yield(i) {
+			return
+		}
+	}
+}
+
+func incr(n int) int        { return n + 1 }
+func double(n int) int      { return n * 2 }
+func notDivBy3(n int) bool  { return n%3 != 0 }
+func belowLimit(n int) bool { return n < 4*benchmarkSize }
+func notDivBy5(n int) bool  { return n%5 != 0 }
+
+// drain consumes it with a yield function that captures nothing, so that the benchmarks only report the allocations
+// of the iterators themselves and not those of a range loop's body
+func drain(it func(func(int) bool)) {
+	it(func(int) bool { return true })
+}
+
+func BenchmarkPipeline_Combinators(b *testing.B) {
+	it := TakeWhile(Filter(Map(Filter(Map(benchmarkSource, incr), notDivBy3), double), notDivBy5), belowLimit)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for range b.N {
+		drain(it)
+	}
+}
+
+func BenchmarkPipeline_Fused(b *testing.B) {
+	it := NewPipeline(benchmarkSource).Map(incr).Filter(notDivBy3).Map(double).Filter(notDivBy5).TakeWhile(belowLimit).All()
+	b.ReportAllocs()
+	b.ResetTimer()
+	for range b.N {
+		drain(it)
+	}
+}
+
+func BenchmarkPipeline_MapChainCombinators(b *testing.B) {
+	it := Map(Map(Map(Map(Map(Map(benchmarkSource, incr), double), incr), double), incr), double)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for range b.N {
+		drain(it)
+	}
+}
+
+func BenchmarkPipeline_MapChainFused(b *testing.B) {
+	it := NewPipeline(benchmarkSource).Map(incr).Map(double).Map(incr).Map(double).Map(incr).Map(double).All()
+	b.ReportAllocs()
+	b.ResetTimer()
+	for range b.N {
+		drain(it)
+	}
+}
@@ -0,0 +1,135 @@
+package iterator
+
+import (
+	xConstraints "go-exp/constraints"
+	"go-exp/functions/reducers"
+	"golang.org/x/exp/constraints"
+)
+
+// Lag returns an iterator that yields each element of it along with the element n positions before it, which is nil
+// for the first n elements
+func Lag[T any](it func(func(T) bool), n int) func(func(T, *T) bool) {
+	if n <= 0 {
+		panic("iterator: lag offset must be positive")
+	}
+	return func(yield func(T, *T) bool) {
+		ring := make([]T, n)
+		i := 0
+		for t := range it {
+			var prev *T
+			if i >= n {
+				v := ring[i%n]
+				prev = &v
+			}
+			ring[i%n] = t
+			i++
+			if !yield(t, prev) {
+				return
+			}
+		}
+	}
+}
+
+// Lead returns an iterator that yields each element of it along with the element n positions after it, which is nil
+// for the last n elements. Each element is yielded once the element n positions after it has been read.
+func Lead[T any](it func(func(T) bool), n int) func(func(T, *T) bool) {
+	if n <= 0 {
+		panic("iterator: lead offset must be positive")
+	}
+	return func(yield func(T, *T) bool) {
+		ring := make([]T, n)
+		i := 0
+		for t := range it {
+			if i >= n {
+				next := t
+				if !yield(ring[i%n], &next) {
+					return
+				}
+			}
+			ring[i%n] = t
+			i++
+		}
+		for j := max(i-n, 0); j < i; j++ {
+			if !yield(ring[j%n], nil) {
+				return
+			}
+		}
+	}
+}
+
+// RollingSum yields the sum of every full window of size consecutive elements, starting once the first size elements
+// have been read. It uses compensated summation so that adding and removing elements doesn't accumulate rounding
+// errors.
+func RollingSum[T xConstraints.Real](it func(func(T) bool), size int) func(func(float64) bool) {
+	if size <= 0 {
+		panic("iterator: window size must be positive")
+	}
+	return func(yield func(float64) bool) {
+		ring := make([]T, size)
+		var sum reducers.Sum
+		i := 0
+		for t := range it {
+			if i >= size {
+				sum = reducers.AddSum(sum, -float64(ring[i%size]))
+			}
+			sum = reducers.AddSum(sum, t)
+			ring[i%size] = t
+			i++
+			if i >= size && !yield(sum.Value()) {
+				return
+			}
+		}
+	}
+}
+
+// RollingMean is like RollingSum, but yields the mean of each window
+func RollingMean[T xConstraints.Real](it func(func(T) bool), size int) func(func(float64) bool) {
+	return Map(RollingSum(it, size), func(sum float64) float64 {
+		return sum / float64(size)
+	})
+}
+
+// RollingMin yields the minimum of every full window of size consecutive elements, like RollingSum, in amortized
+// constant time per element
+func RollingMin[T constraints.Ordered](it func(func(T) bool), size int) func(func(T) bool) {
+	return rollingExtreme(it, size, func(a, b T) bool { return a <= b })
+}
+
+// RollingMax is like RollingMin, but yields the maximum of each window
+func RollingMax[T constraints.Ordered](it func(func(T) bool), size int) func(func(T) bool) {
+	return rollingExtreme(it, size, func(a, b T) bool { return a >= b })
+}
+
+type dequeItem[T any] struct {
+	v T
+	i int
+}
+
+// rollingExtreme keeps a monotonic deque of the elements in the window that could still become its extreme, where
+// before(a, b) reports whether a is at least as extreme as b. The front of the deque is the extreme of the window.
+func rollingExtreme[T any](it func(func(T) bool), size int, before func(a, b T) bool) func(func(T) bool) {
+	if size <= 0 {
+		panic("iterator: window size must be positive")
+	}
+	return func(yield func(T) bool) {
+		// the deque never holds more than size items, so it lives in a ring buffer
+		ring := make([]dequeItem[T], size)
+		head, n := 0, 0
+		i := 0
+		for t := range it {
+			if n > 0 && ring[head].i <= i-size {
+				head = (head + 1) % size
+				n--
+			}
+			for n > 0 && before(t, ring[(head+n-1)%size].v) {
+				n--
+			}
+			ring[(head+n)%size] = dequeItem[T]{t, i}
+			n++
+			i++
+			if i >= size && !yield(ring[head].v) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,65 @@
+package iterator
+
+import (
+	"go-exp/pointer"
+	"gotest.tools/v3/assert"
+	"math/rand/v2"
+	"slices"
+	"testing"
+)
+
+func collectOffsets(it func(func(int, *int) bool)) [][2]int {
+	var result [][2]int
+	for t, other := range it {
+		result = append(result, [2]int{t, pointer.GetOrElse(other, -1)})
+	}
+	return result
+}
+
+func TestLag(t *testing.T) {
+	it := slices.Values([]int{1, 2, 3, 4, 5})
+	assert.DeepEqual(t, collectOffsets(Lag(it, 2)), [][2]int{{1, -1}, {2, -1}, {3, 1}, {4, 2}, {5, 3}})
+	assert.DeepEqual(t, collectOffsets(Lag(it, 1)), [][2]int{{1, -1}, {2, 1}, {3, 2}, {4, 3}, {5, 4}})
+}
+
+func TestLead(t *testing.T) {
+	it := slices.Values([]int{1, 2, 3, 4, 5})
+	assert.DeepEqual(t, collectOffsets(Lead(it, 2)), [][2]int{{1, 3}, {2, 4}, {3, 5}, {4, -1}, {5, -1}})
+	assert.DeepEqual(t, collectOffsets(Lead(it, 7)), [][2]int{{1, -1}, {2, -1}, {3, -1}, {4, -1}, {5, -1}})
+}
+
+func TestRollingSum(t *testing.T) {
+	it := slices.Values([]int{1, 2, 3, 4, 5})
+	assert.DeepEqual(t, slices.Collect(RollingSum(it, 3)), []float64{6, 9, 12})
+	assert.DeepEqual(t, slices.Collect(RollingMean(it, 2)), []float64{1.5, 2.5, 3.5, 4.5})
+	assert.DeepEqual(t, slices.Collect(RollingSum(it, 6)), []float64(nil))
+
+	// a large value passing through the window doesn't leave rounding errors behind
+	floats := slices.Values([]float64{0.1, 1e17, 0.1, 0.1, 0.1})
+	assert.DeepEqual(t, slices.Collect(RollingSum(floats, 2))[2:], []float64{0.2, 0.2})
+
+	// elements leaving the window are subtracted without wrapping unsigned types
+	assert.DeepEqual(t, slices.Collect(RollingSum(slices.Values([]uint{1, 2, 3, 4}), 2)), []float64{3, 5, 7})
+	assert.DeepEqual(t, slices.Collect(RollingMean(slices.Values([]uint8{10, 20, 30}), 2)), []float64{15, 25})
+}
+
+func TestRollingMinMax(t *testing.T) {
+	it := slices.Values([]int{4, 2, 12, 3, 8, 7, 1, 9})
+	assert.DeepEqual(t, slices.Collect(RollingMin(it, 3)), []int{2, 2, 3, 3, 1, 1})
+	assert.DeepEqual(t, slices.Collect(RollingMax(it, 3)), []int{12, 12, 12, 8, 8, 9})
+	assert.DeepEqual(t, slices.Collect(RollingMax(it, 1)), []int{4, 2, 12, 3, 8, 7, 1, 9})
+}
+
+func TestRollingMinMax_MatchesWindow(t *testing.T) {
+	rng := rand.New(rand.NewPCG(1, 2))
+	nums := make([]int, 500)
+	for i := range nums {
+		nums[i] = rng.IntN(50)
+	}
+
+	for _, size := range []int{1, 2, 5, 17} {
+		windows := Window(slices.Values(nums), size, 1)
+		assert.DeepEqual(t, slices.Collect(RollingMin(slices.Values(nums), size)), slices.Collect(Map(windows, slices.Min)))
+		assert.DeepEqual(t, slices.Collect(RollingMax(slices.Values(nums), size)), slices.Collect(Map(windows, slices.Max)))
+	}
+}
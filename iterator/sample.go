@@ -0,0 +1,98 @@
+package iterator
+
+import (
+	"cmp"
+	"container/heap"
+	"math"
+	"math/rand/v2"
+)
+
+// unitOpen returns a random number in the open interval (0, 1)
+func unitOpen(rng *rand.Rand) float64 {
+	for {
+		if u := rng.Float64(); u > 0 {
+			return u
+		}
+	}
+}
+
+// SampleReservoir returns a uniform random sample of k elements of it, or all of them if there are fewer than k. It
+// uses Algorithm L, which draws random numbers only for the elements that enter the sample. The sample is not in any
+// particular order.
+func SampleReservoir[T any](it func(func(T) bool), k int, rng *rand.Rand) []T {
+	if k <= 0 {
+		return nil
+	}
+
+	reservoir := make([]T, 0, k)
+	var w float64
+	skip := 0
+	nextSkip := func() {
+		w *= math.Exp(math.Log(unitOpen(rng)) / float64(k))
+		skip = int(math.Floor(math.Log(unitOpen(rng)) / math.Log1p(-w)))
+	}
+
+	for t := range it {
+		if len(reservoir) < k {
+			reservoir = append(reservoir, t)
+			if len(reservoir) == k {
+				w = 1
+				nextSkip()
+			}
+			continue
+		}
+		if skip > 0 {
+			skip--
+			continue
+		}
+		reservoir[rng.IntN(k)] = t
+		nextSkip()
+	}
+	return reservoir
+}
+
+// SampleBernoulli returns an iterator that yields each element of it independently with probability p
+func SampleBernoulli[T any](it func(func(T) bool), p float64, rng *rand.Rand) func(func(T) bool) {
+	return Filter(it, func(T) bool {
+		return rng.Float64() < p
+	})
+}
+
+type weightedItem[T any] struct {
+	v   T
+	key float64
+}
+
+// SampleWeightedReservoir returns a random sample of k elements of it without replacement, where the chance of each
+// element being included is proportional to its weight. Elements with a weight that isn't positive are never
+// included. It uses the A-Res algorithm of Efraimidis and Spirakis.
+func SampleWeightedReservoir[T any](it func(func(T) bool), k int, weight func(T) float64, rng *rand.Rand) []T {
+	if k <= 0 {
+		return nil
+	}
+
+	// a min-heap of the items with the largest keys seen so far
+	h := &indexedHeap[weightedItem[T]]{cmp: func(a, b weightedItem[T]) int {
+		return cmp.Compare(a.key, b.key)
+	}}
+	for t := range it {
+		w := weight(t)
+		if w <= 0 {
+			continue
+		}
+		// comparing log(u)/w orders items the same as u^(1/w), without underflowing for small weights
+		item := weightedItem[T]{t, math.Log(unitOpen(rng)) / w}
+		if h.Len() < k {
+			heap.Push(h, indexedItem[weightedItem[T]]{v: item})
+		} else if item.key > h.items[0].v.key {
+			h.items[0].v = item
+			heap.Fix(h, 0)
+		}
+	}
+
+	sample := make([]T, h.Len())
+	for i, item := range h.items {
+		sample[i] = item.v.v
+	}
+	return sample
+}
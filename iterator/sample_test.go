@@ -0,0 +1,69 @@
+package iterator
+
+import (
+	"gotest.tools/v3/assert"
+	"math/rand/v2"
+	"slices"
+	"testing"
+)
+
+func newRand() *rand.Rand {
+	return rand.New(rand.NewPCG(1, 2))
+}
+
+func TestSampleReservoir(t *testing.T) {
+	it := Range(0, 1000, 1)
+
+	sample := SampleReservoir(it, 10, newRand())
+	assert.Equal(t, len(sample), 10)
+	assert.DeepEqual(t, SampleReservoir(it, 10, newRand()), sample)
+	assert.Equal(t, len(slices.Collect(Distinct(slices.Values(sample)))), 10)
+
+	assert.DeepEqual(t, SampleReservoir(Range(0, 3, 1), 10, newRand()), []int{0, 1, 2})
+}
+
+func TestSampleReservoir_Uniform(t *testing.T) {
+	rng := newRand()
+	counts := make([]int, 20)
+	for range 5000 {
+		for _, n := range SampleReservoir(Range(0, 20, 1), 5, rng) {
+			counts[n]++
+		}
+	}
+
+	// each element is expected 1250 times
+	for _, c := range counts {
+		assert.Assert(t, c > 1100 && c < 1400, "count %d", c)
+	}
+}
+
+func TestSampleBernoulli(t *testing.T) {
+	it := Range(0, 10000, 1)
+
+	sample := slices.Collect(SampleBernoulli(it, 0.1, newRand()))
+	assert.Assert(t, len(sample) > 900 && len(sample) < 1100, "len %d", len(sample))
+	assert.Assert(t, slices.IsSorted(sample))
+	assert.DeepEqual(t, slices.Collect(SampleBernoulli(it, 0.1, newRand())), sample)
+}
+
+func TestSampleWeightedReservoir(t *testing.T) {
+	rng := newRand()
+	weight := func(n int) float64 { return float64(n) }
+
+	counts := make([]int, 4)
+	for range 3000 {
+		for _, n := range SampleWeightedReservoir(Range(0, 4, 1), 1, weight, rng) {
+			counts[n]++
+		}
+	}
+
+	// weights 0, 1, 2 and 3 give expected counts of 0, 500, 1000 and 1500
+	assert.Equal(t, counts[0], 0)
+	assert.Assert(t, counts[1] > 400 && counts[1] < 600, "counts %v", counts)
+	assert.Assert(t, counts[2] > 880 && counts[2] < 1120, "counts %v", counts)
+	assert.Assert(t, counts[3] > 1380 && counts[3] < 1620, "counts %v", counts)
+
+	sample := SampleWeightedReservoir(Range(0, 100, 1), 5, weight, newRand())
+	assert.Equal(t, len(sample), 5)
+	assert.DeepEqual(t, SampleWeightedReservoir(Range(0, 100, 1), 5, weight, newRand()), sample)
+}
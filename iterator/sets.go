@@ -0,0 +1,198 @@
+package iterator
+
+import (
+	"cmp"
+	"container/heap"
+	"golang.org/x/exp/constraints"
+)
+
+// Union yields every element that is present in any of the iterators, once. The iterators must be sorted in ascending
+// order, duplicates within an iterator are ignored, and the result is sorted.
+func Union[T constraints.Ordered](its ...func(func(T) bool)) func(func(T) bool) {
+	return UnionFunc(cmp.Compare[T], its...)
+}
+
+// UnionFunc is like Union, for iterators sorted in ascending order by cmp
+func UnionFunc[T any](cmp func(a, b T) int, its ...func(func(T) bool)) func(func(T) bool) {
+	return sortedSetOp(cmp, its, func(counts []int) int {
+		return 1
+	})
+}
+
+// Intersect yields every element that is present in all the iterators, once. The iterators must be sorted in ascending
+// order, duplicates within an iterator are ignored, and the result is sorted.
+func Intersect[T constraints.Ordered](its ...func(func(T) bool)) func(func(T) bool) {
+	return IntersectFunc(cmp.Compare[T], its...)
+}
+
+// IntersectFunc is like Intersect, for iterators sorted in ascending order by cmp
+func IntersectFunc[T any](cmp func(a, b T) int, its ...func(func(T) bool)) func(func(T) bool) {
+	return sortedSetOp(cmp, its, func(counts []int) int {
+		return min(1, minCount(counts))
+	})
+}
+
+// Difference yields every element of it that is not present in any of the other iterators, once. The iterators must be
+// sorted in ascending order, duplicates within an iterator are ignored, and the result is sorted.
+func Difference[T constraints.Ordered](it func(func(T) bool), others ...func(func(T) bool)) func(func(T) bool) {
+	return DifferenceFunc(cmp.Compare[T], it, others...)
+}
+
+// DifferenceFunc is like Difference, for iterators sorted in ascending order by cmp
+func DifferenceFunc[T any](cmp func(a, b T) int, it func(func(T) bool), others ...func(func(T) bool)) func(func(T) bool) {
+	return sortedSetOp(cmp, append([]func(func(T) bool){it}, others...), func(counts []int) int {
+		if counts[0] > 0 && maxCount(counts[1:]) == 0 {
+			return 1
+		}
+		return 0
+	})
+}
+
+// SymmetricDifference yields every element that is present in an odd number of the iterators, once, as folding the
+// iterators with the two-input symmetric difference would. The iterators must be sorted in ascending order,
+// duplicates within an iterator are ignored, and the result is sorted.
+func SymmetricDifference[T constraints.Ordered](its ...func(func(T) bool)) func(func(T) bool) {
+	return SymmetricDifferenceFunc(cmp.Compare[T], its...)
+}
+
+// SymmetricDifferenceFunc is like SymmetricDifference, for iterators sorted in ascending order by cmp
+func SymmetricDifferenceFunc[T any](cmp func(a, b T) int, its ...func(func(T) bool)) func(func(T) bool) {
+	return sortedSetOp(cmp, its, func(counts []int) int {
+		present := 0
+		for _, c := range counts {
+			if c > 0 {
+				present++
+			}
+		}
+		return present % 2
+	})
+}
+
+// MultisetUnion yields every element as many times as the largest number of times it occurs in any of the iterators.
+// The iterators must be sorted in ascending order and the result is sorted.
+func MultisetUnion[T constraints.Ordered](its ...func(func(T) bool)) func(func(T) bool) {
+	return MultisetUnionFunc(cmp.Compare[T], its...)
+}
+
+// MultisetUnionFunc is like MultisetUnion, for iterators sorted in ascending order by cmp
+func MultisetUnionFunc[T any](cmp func(a, b T) int, its ...func(func(T) bool)) func(func(T) bool) {
+	return sortedSetOp(cmp, its, maxCount)
+}
+
+// MultisetIntersect yields every element as many times as the smallest number of times it occurs in any of the
+// iterators. The iterators must be sorted in ascending order and the result is sorted.
+func MultisetIntersect[T constraints.Ordered](its ...func(func(T) bool)) func(func(T) bool) {
+	return MultisetIntersectFunc(cmp.Compare[T], its...)
+}
+
+// MultisetIntersectFunc is like MultisetIntersect, for iterators sorted in ascending order by cmp
+func MultisetIntersectFunc[T any](cmp func(a, b T) int, its ...func(func(T) bool)) func(func(T) bool) {
+	return sortedSetOp(cmp, its, minCount)
+}
+
+// MultisetDifference yields every element of it as many times as it occurs in it, less the number of times it occurs in
+// the other iterators. The iterators must be sorted in ascending order and the result is sorted.
+func MultisetDifference[T constraints.Ordered](it func(func(T) bool), others ...func(func(T) bool)) func(func(T) bool) {
+	return MultisetDifferenceFunc(cmp.Compare[T], it, others...)
+}
+
+// MultisetDifferenceFunc is like MultisetDifference, for iterators sorted in ascending order by cmp
+func MultisetDifferenceFunc[T any](cmp func(a, b T) int, it func(func(T) bool), others ...func(func(T) bool)) func(func(T) bool) {
+	return sortedSetOp(cmp, append([]func(func(T) bool){it}, others...), func(counts []int) int {
+		n := counts[0]
+		for _, c := range counts[1:] {
+			n -= c
+		}
+		return max(n, 0)
+	})
+}
+
+// MultisetSymmetricDifference yields every element as many times as the difference between the largest and smallest
+// number of times it occurs in any of the iterators. The iterators must be sorted in ascending order and the result is
+// sorted.
+func MultisetSymmetricDifference[T constraints.Ordered](its ...func(func(T) bool)) func(func(T) bool) {
+	return MultisetSymmetricDifferenceFunc(cmp.Compare[T], its...)
+}
+
+// MultisetSymmetricDifferenceFunc is like MultisetSymmetricDifference, for iterators sorted in ascending order by cmp
+func MultisetSymmetricDifferenceFunc[T any](cmp func(a, b T) int, its ...func(func(T) bool)) func(func(T) bool) {
+	return sortedSetOp(cmp, its, func(counts []int) int {
+		return maxCount(counts) - minCount(counts)
+	})
+}
+
+func minCount(counts []int) int {
+	if len(counts) == 0 {
+		return 0
+	}
+	n := counts[0]
+	for _, c := range counts[1:] {
+		n = min(n, c)
+	}
+	return n
+}
+
+func maxCount(counts []int) int {
+	n := 0
+	for _, c := range counts {
+		n = max(n, c)
+	}
+	return n
+}
+
+// sortedSetOp yields each distinct element of the merged iterators as many times as fn returns for the number of
+// times it occurs in each iterator
+func sortedSetOp[T any](cmp func(T, T) int, its []func(func(T) bool), fn func(counts []int) int) func(func(T) bool) {
+	return func(yield func(T) bool) {
+		for v, counts := range sortedGroups(cmp, its) {
+			for range fn(counts) {
+				if !yield(v) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// sortedGroups merges sorted iterators and yields each distinct element along with the number of times it occurs in
+// each iterator. The element yielded is taken from the first iterator it occurs in, and the counts slice is reused
+// between yields.
+func sortedGroups[T any](cmp func(T, T) int, its []func(func(T) bool)) func(func(T, []int) bool) {
+	return func(yield func(T, []int) bool) {
+		pulls := pullAll(its)
+		defer func() {
+			for _, it := range pulls {
+				it.stop()
+			}
+		}()
+
+		h := &indexedHeap[T]{cmp: cmp, stable: true}
+		for i, it := range pulls {
+			if v, ok := it.next(); ok {
+				h.items = append(h.items, indexedItem[T]{v, i})
+			}
+		}
+		heap.Init(h)
+
+		counts := make([]int, len(pulls))
+		for h.Len() > 0 {
+			item := heap.Pop(h).(indexedItem[T])
+			v := item.v
+			clear(counts)
+			for {
+				counts[item.i]++
+				if next, ok := pulls[item.i].next(); ok {
+					heap.Push(h, indexedItem[T]{next, item.i})
+				}
+				if h.Len() == 0 || cmp(h.items[0].v, v) != 0 {
+					break
+				}
+				item = heap.Pop(h).(indexedItem[T])
+			}
+
+			if !yield(v, counts) {
+				return
+			}
+		}
+	}
+}
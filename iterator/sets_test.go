@@ -0,0 +1,66 @@
+package iterator
+
+import (
+	"gotest.tools/v3/assert"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestSetOperations(t *testing.T) {
+	a := []int{1, 2, 2, 3, 5, 8}
+	b := []int{2, 3, 3, 4, 8}
+	c := []int{0, 2, 3, 8, 8, 9}
+
+	tests := []struct {
+		name string
+		op   func(its ...func(func(int) bool)) func(func(int) bool)
+		want []int
+	}{
+		{"Union", Union[int], []int{0, 1, 2, 3, 4, 5, 8, 9}},
+		{"Intersect", Intersect[int], []int{2, 3, 8}},
+		{"SymmetricDifference", SymmetricDifference[int], []int{0, 1, 2, 3, 4, 5, 8, 9}},
+		{"MultisetUnion", MultisetUnion[int], []int{0, 1, 2, 2, 3, 3, 4, 5, 8, 8, 9}},
+		{"MultisetIntersect", MultisetIntersect[int], []int{2, 3, 8}},
+		{"MultisetSymmetricDifference", MultisetSymmetricDifference[int], []int{0, 1, 2, 3, 4, 5, 8, 9}},
+	}
+
+	for _, tc := range tests {
+		result := slices.Collect(tc.op(slices.Values(a), slices.Values(b), slices.Values(c)))
+		assert.DeepEqual(t, result, tc.want)
+	}
+}
+
+func TestSymmetricDifference(t *testing.T) {
+	// elements in two of the three inputs are dropped, while those in one or all three are kept
+	result := slices.Collect(SymmetricDifference(
+		slices.Values([]int{1, 2, 5}), slices.Values([]int{2, 3, 5}), slices.Values([]int{1, 4, 5})))
+	assert.DeepEqual(t, result, []int{3, 4, 5})
+}
+
+func TestDifference(t *testing.T) {
+	a := slices.Values([]int{1, 1, 2, 3, 3, 3, 5, 8})
+	b := slices.Values([]int{3, 4, 8})
+	c := slices.Values([]int{1, 3})
+
+	assert.DeepEqual(t, slices.Collect(Difference(a, b, c)), []int{2, 5})
+	assert.DeepEqual(t, slices.Collect(MultisetDifference(a, b, c)), []int{1, 2, 3, 5})
+}
+
+func TestIntersectFunc(t *testing.T) {
+	a := slices.Values([]string{"A", "b", "C", "d"})
+	b := slices.Values([]string{"a", "c", "D"})
+
+	result := slices.Collect(IntersectFunc(func(x, y string) int {
+		return strings.Compare(strings.ToLower(x), strings.ToLower(y))
+	}, a, b))
+	assert.DeepEqual(t, result, []string{"A", "C", "d"})
+}
+
+func TestUnion_EarlyTermination(t *testing.T) {
+	a := Generate(0, func(x int) int { return x + 2 })
+	b := Generate(1, func(x int) int { return x + 2 })
+
+	result := slices.Collect(TakeWhile(Union(a, b), func(x int) bool { return x < 5 }))
+	assert.DeepEqual(t, result, []int{0, 1, 2, 3, 4})
+}
@@ -0,0 +1,187 @@
+package iterator
+
+import (
+	"bufio"
+	"container/heap"
+	"encoding/gob"
+	"errors"
+	"io"
+	"os"
+	"slices"
+)
+
+// TopK returns the k largest elements of it according to cmp, in descending order. Only k elements are held in memory
+// at once.
+func TopK[T any](it func(func(T) bool), k int, cmp func(a, b T) int) []T {
+	if k <= 0 {
+		return nil
+	}
+
+	// a min-heap of the largest elements seen so far, with the smallest of them on top
+	h := &indexedHeap[T]{cmp: cmp}
+	for t := range it {
+		if h.Len() < k {
+			heap.Push(h, indexedItem[T]{v: t})
+		} else if cmp(t, h.items[0].v) > 0 {
+			h.items[0].v = t
+			heap.Fix(h, 0)
+		}
+	}
+
+	result := make([]T, h.Len())
+	for i := len(result) - 1; i >= 0; i-- {
+		result[i] = heap.Pop(h).(indexedItem[T]).v
+	}
+	return result
+}
+
+type Encoder[T any] interface {
+	Encode(T) error
+}
+
+// Decoder decodes values written by the matching Encoder, returning io.EOF once there are no more
+type Decoder[T any] interface {
+	Decode() (T, error)
+}
+
+// Codec serializes the sorted runs that SortExternal spills to temporary files
+type Codec[T any] interface {
+	NewEncoder(io.Writer) Encoder[T]
+	NewDecoder(io.Reader) Decoder[T]
+}
+
+// GobCodec is a Codec that uses encoding/gob
+type GobCodec[T any] struct{}
+
+type gobEncoder[T any] struct {
+	enc *gob.Encoder
+}
+
+func (e gobEncoder[T]) Encode(t T) error {
+	return e.enc.Encode(t)
+}
+
+type gobDecoder[T any] struct {
+	dec *gob.Decoder
+}
+
+func (d gobDecoder[T]) Decode() (T, error) {
+	var t T
+	err := d.dec.Decode(&t)
+	return t, err
+}
+
+func (GobCodec[T]) NewEncoder(w io.Writer) Encoder[T] {
+	return gobEncoder[T]{gob.NewEncoder(w)}
+}
+
+func (GobCodec[T]) NewDecoder(r io.Reader) Decoder[T] {
+	return gobDecoder[T]{gob.NewDecoder(r)}
+}
+
+type ExternalSortOptions[T any] struct {
+	// RunSize is the number of elements sorted in memory before being spilled to a temporary file. Defaults to 1<<20.
+	RunSize int
+	// Codec serializes spilled elements. Defaults to GobCodec.
+	Codec Codec[T]
+	// TempDir is the directory for temporary files. Defaults to os.TempDir().
+	TempDir string
+}
+
+// SortExternal returns a fallible iterator over the elements of it, stably sorted according to cmp. Elements are
+// sorted in runs of opts.RunSize, which are spilled to temporary files and merged back together, so only about one
+// run is held in memory at once. Temporary files are removed when iteration ends. An error reading or writing a
+// temporary file is yielded at the end of the iteration.
+func SortExternal[T any](it func(func(T) bool), cmp func(a, b T) int, opts ExternalSortOptions[T]) func(func(T, error) bool) {
+	if opts.RunSize <= 0 {
+		opts.RunSize = 1 << 20
+	}
+	if opts.Codec == nil {
+		opts.Codec = GobCodec[T]{}
+	}
+	return func(yield func(T, error) bool) {
+		var zero T
+		var files []*os.File
+		defer func() {
+			for _, f := range files {
+				f.Close()
+				os.Remove(f.Name())
+			}
+		}()
+
+		var run []T
+		for t := range it {
+			run = append(run, t)
+			if len(run) < opts.RunSize {
+				continue
+			}
+			slices.SortStableFunc(run, cmp)
+			f, err := spillRun(run, opts)
+			if f != nil {
+				files = append(files, f)
+			}
+			if err != nil {
+				yield(zero, err)
+				return
+			}
+			run = run[:0]
+		}
+		slices.SortStableFunc(run, cmp)
+
+		var readErr error
+		runs := make([]func(func(T) bool), 0, len(files)+1)
+		for _, f := range files {
+			runs = append(runs, decodeRun(f, opts.Codec, &readErr))
+		}
+		// the last run is the most recent input, so it goes last to keep the merge stable
+		runs = append(runs, slices.Values(run))
+
+		for t := range MergeOrderedStableFunc(cmp, runs...) {
+			if !yield(t, nil) {
+				return
+			}
+		}
+		if readErr != nil {
+			yield(zero, readErr)
+		}
+	}
+}
+
+func spillRun[T any](run []T, opts ExternalSortOptions[T]) (*os.File, error) {
+	f, err := os.CreateTemp(opts.TempDir, "iterator-sort-*")
+	if err != nil {
+		return nil, err
+	}
+
+	w := bufio.NewWriter(f)
+	enc := opts.Codec.NewEncoder(w)
+	for _, t := range run {
+		if err := enc.Encode(t); err != nil {
+			return f, err
+		}
+	}
+	if err := w.Flush(); err != nil {
+		return f, err
+	}
+	_, err = f.Seek(0, io.SeekStart)
+	return f, err
+}
+
+// decodeRun returns an iterator over the elements of a spilled run, recording the first decoding error in err
+func decodeRun[T any](f *os.File, codec Codec[T], err *error) func(func(T) bool) {
+	return func(yield func(T) bool) {
+		dec := codec.NewDecoder(bufio.NewReader(f))
+		for {
+			t, decErr := dec.Decode()
+			if decErr != nil {
+				if !errors.Is(decErr, io.EOF) && *err == nil {
+					*err = decErr
+				}
+				return
+			}
+			if !yield(t) {
+				return
+			}
+		}
+	}
+}
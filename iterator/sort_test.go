@@ -0,0 +1,104 @@
+package iterator
+
+import (
+	"bufio"
+	"cmp"
+	"fmt"
+	"gotest.tools/v3/assert"
+	"io"
+	"os"
+	"slices"
+	"testing"
+)
+
+func TestTopK(t *testing.T) {
+	it := slices.Values([]int{5, 1, 9, 3, 7, 9, 2})
+
+	assert.DeepEqual(t, TopK(it, 3, cmp.Compare[int]), []int{9, 9, 7})
+	assert.DeepEqual(t, TopK(it, 10, cmp.Compare[int]), []int{9, 9, 7, 5, 3, 2, 1})
+	assert.Equal(t, len(TopK(it, 0, cmp.Compare[int])), 0)
+}
+
+type sortRecord struct {
+	Key, Seq int
+}
+
+func compareKeys(a, b sortRecord) int {
+	return a.Key - b.Key
+}
+
+func TestSortExternal(t *testing.T) {
+	dir := t.TempDir()
+	var input []sortRecord
+	for i := range 20 {
+		input = append(input, sortRecord{(i * 7) % 5, i})
+	}
+
+	result, err := TryCollect(SortExternal(slices.Values(input), compareKeys,
+		ExternalSortOptions[sortRecord]{RunSize: 3, TempDir: dir}))
+	assert.NilError(t, err)
+
+	expected := slices.Clone(input)
+	slices.SortStableFunc(expected, compareKeys)
+	assert.DeepEqual(t, result, expected)
+
+	entries, err := os.ReadDir(dir)
+	assert.NilError(t, err)
+	assert.Equal(t, len(entries), 0)
+}
+
+func TestSortExternal_EarlyTermination(t *testing.T) {
+	dir := t.TempDir()
+	it := SortExternal(Range(100, 0, -1), cmp.Compare[int], ExternalSortOptions[int]{RunSize: 10, TempDir: dir})
+
+	var result []int
+	for n, err := range it {
+		assert.NilError(t, err)
+		result = append(result, n)
+		if n == 3 {
+			break
+		}
+	}
+	assert.DeepEqual(t, result, []int{1, 2, 3})
+
+	entries, err := os.ReadDir(dir)
+	assert.NilError(t, err)
+	assert.Equal(t, len(entries), 0)
+}
+
+// lineCodec writes ints as text, and fails to decode negative numbers
+type lineCodec struct{}
+
+type lineEncoder struct{ w io.Writer }
+
+func (e lineEncoder) Encode(n int) error {
+	_, err := fmt.Fprintln(e.w, n)
+	return err
+}
+
+type lineDecoder struct{ r *bufio.Reader }
+
+func (d lineDecoder) Decode() (int, error) {
+	var n int
+	if _, err := fmt.Fscanln(d.r, &n); err != nil {
+		return 0, err
+	}
+	if n < 0 {
+		return 0, fmt.Errorf("negative: %d", n)
+	}
+	return n, nil
+}
+
+func (lineCodec) NewEncoder(w io.Writer) Encoder[int] { return lineEncoder{w} }
+func (lineCodec) NewDecoder(r io.Reader) Decoder[int] { return lineDecoder{bufio.NewReader(r)} }
+
+func TestSortExternal_Codec(t *testing.T) {
+	opts := ExternalSortOptions[int]{RunSize: 2, Codec: lineCodec{}, TempDir: t.TempDir()}
+
+	result, err := TryCollect(SortExternal(slices.Values([]int{4, 3, 2, 1, 0}), cmp.Compare[int], opts))
+	assert.NilError(t, err)
+	assert.DeepEqual(t, result, []int{0, 1, 2, 3, 4})
+
+	_, err = TryCollect(SortExternal(slices.Values([]int{4, 3, -2, 1, 0}), cmp.Compare[int], opts))
+	assert.ErrorContains(t, err, "negative: -2")
+}
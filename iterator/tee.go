@@ -0,0 +1,173 @@
+package iterator
+
+import (
+	"errors"
+	"iter"
+	"sync"
+)
+
+var ErrTeeLag = errors.New("iterator: tee branch fell too far behind")
+
+// LagPolicy controls what a bounded tee does when a branch falls too far behind the branch that is furthest ahead
+type LagPolicy int
+
+const (
+	// BlockLeader makes branches wait before pulling a new element until the slowest branch catches up. Branches must
+	// be consumed concurrently, since a branch that runs ahead blocks until the others make progress.
+	BlockLeader LagPolicy = iota
+	// DropSlow makes slow branches skip the elements that no longer fit in the buffer
+	DropSlow
+	// ErrorSlow makes slow branches yield ErrTeeLag and stop
+	ErrorSlow
+)
+
+type boundedTeeState[T any] struct {
+	next   func() (T, bool)
+	stop   func()
+	maxLag int
+	policy LagPolicy
+
+	mu   sync.Mutex
+	cond *sync.Cond
+	buf  []T
+	// start is the absolute position of buf[0]
+	start     int
+	positions []int
+	// active branches hold back trimming of the buffer and may be dropped or errored when they fall behind
+	active    []bool
+	lagged    []bool
+	finished  []bool
+	nFinished int
+	exhausted bool
+}
+
+func (s *boundedTeeState[T]) end() int {
+	return s.start + len(s.buf)
+}
+
+func (s *boundedTeeState[T]) minPosition() int {
+	minPos := s.end()
+	for j, pos := range s.positions {
+		if s.active[j] {
+			minPos = min(minPos, pos)
+		}
+	}
+	return minPos
+}
+
+// trim drops the buffered elements that every active branch has already yielded
+func (s *boundedTeeState[T]) trim() {
+	if n := s.minPosition() - s.start; n > 0 {
+		clear(s.buf[:n])
+		s.buf = s.buf[n:]
+		s.start += n
+	}
+}
+
+func (s *boundedTeeState[T]) advanceOne(i int) (T, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var zero T
+	for {
+		if s.lagged[i] {
+			return zero, false, ErrTeeLag
+		}
+		if pos := s.positions[i]; pos < s.end() {
+			s.positions[i]++
+			t := s.buf[pos-s.start]
+			s.trim()
+			s.cond.Broadcast()
+			return t, true, nil
+		}
+		if s.exhausted {
+			return zero, false, nil
+		}
+		if s.policy == BlockLeader && s.end()-s.minPosition() >= s.maxLag {
+			s.cond.Wait()
+			continue
+		}
+
+		t, ok := s.next()
+		if !ok {
+			s.exhausted = true
+			s.cond.Broadcast()
+			return zero, false, nil
+		}
+		s.buf = append(s.buf, t)
+		for j, pos := range s.positions {
+			if !s.active[j] || s.end()-pos <= s.maxLag {
+				continue
+			}
+			if s.policy == DropSlow {
+				s.positions[j] = s.end() - s.maxLag
+			} else {
+				s.lagged[j] = true
+				s.active[j] = false
+			}
+		}
+		s.trim()
+	}
+}
+
+// deactivate records that branch i will not pull any more elements, stopping the underlying iterator once no branch
+// is active
+func (s *boundedTeeState[T]) deactivate(i int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.finished[i] {
+		return
+	}
+	s.active[i] = false
+	s.finished[i] = true
+	s.nFinished++
+	if s.nFinished == len(s.finished) {
+		s.stop()
+	}
+	s.trim()
+	s.cond.Broadcast()
+}
+
+// BoundedTee is like Tee, but buffers at most maxLag elements for a branch that falls behind the branch that is
+// furthest ahead. policy decides what happens when a branch would fall further behind. The branches yield a non-nil
+// error only under ErrorSlow. Each branch may be consumed from its own goroutine and stopped independently.
+func BoundedTee[T any](it func(func(T) bool), n, maxLag int, policy LagPolicy) []func(func(T, error) bool) {
+	if maxLag <= 0 {
+		panic("iterator: maxLag must be positive")
+	}
+	next, stop := iter.Pull(it)
+
+	state := &boundedTeeState[T]{
+		next:      next,
+		stop:      stop,
+		maxLag:    maxLag,
+		policy:    policy,
+		positions: make([]int, n),
+		active:    make([]bool, n),
+		lagged:    make([]bool, n),
+		finished:  make([]bool, n),
+	}
+	state.cond = sync.NewCond(&state.mu)
+	for i := range state.active {
+		state.active[i] = true
+	}
+
+	outs := make([]func(func(T, error) bool), n)
+	for i := range n {
+		outs[i] = func(yield func(T, error) bool) {
+			defer state.deactivate(i)
+			for {
+				t, ok, err := state.advanceOne(i)
+				if err != nil {
+					yield(t, err)
+					return
+				}
+				if !ok || !yield(t, nil) {
+					return
+				}
+			}
+		}
+	}
+	return outs
+}
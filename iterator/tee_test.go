@@ -0,0 +1,93 @@
+package iterator
+
+import (
+	"gotest.tools/v3/assert"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+func collectTee[T any](it func(func(T, error) bool)) ([]T, error) {
+	var result []T
+	for v, err := range it {
+		if err != nil {
+			return result, err
+		}
+		result = append(result, v)
+	}
+	return result, nil
+}
+
+func TestBoundedTee_BlockLeader(t *testing.T) {
+	var produced atomic.Int32
+	it := Map(slices.Values([]int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}), func(n int) int {
+		produced.Add(1)
+		return n
+	})
+	its := BoundedTee(it, 2, 3, BlockLeader)
+
+	var fast []int
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		fast, _ = collectTee(its[0])
+	}()
+
+	var slow []int
+	for v, err := range its[1] {
+		assert.NilError(t, err)
+		assert.Assert(t, int(produced.Load())-len(slow) <= 3)
+		slow = append(slow, v)
+	}
+	wg.Wait()
+
+	expected := []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}
+	assert.DeepEqual(t, fast, expected)
+	assert.DeepEqual(t, slow, expected)
+}
+
+func TestBoundedTee_BlockLeaderEarlyStop(t *testing.T) {
+	its := BoundedTee(slices.Values([]int{1, 2, 3, 4, 5}), 2, 1, BlockLeader)
+
+	for range its[0] {
+		break
+	}
+	result, err := collectTee(its[1])
+	assert.NilError(t, err)
+	assert.DeepEqual(t, result, []int{1, 2, 3, 4, 5})
+}
+
+func TestBoundedTee_DropSlow(t *testing.T) {
+	its := BoundedTee(slices.Values([]int{1, 2, 3, 4, 5, 6}), 2, 2, DropSlow)
+
+	result1, err := collectTee(its[0])
+	assert.NilError(t, err)
+	result2, err := collectTee(its[1])
+	assert.NilError(t, err)
+
+	assert.DeepEqual(t, result1, []int{1, 2, 3, 4, 5, 6})
+	assert.DeepEqual(t, result2, []int{5, 6})
+}
+
+func TestBoundedTee_ErrorSlow(t *testing.T) {
+	its := BoundedTee(slices.Values([]int{1, 2, 3, 4, 5, 6}), 3, 3, ErrorSlow)
+
+	var result1 []int
+	for v, err := range its[1] {
+		assert.NilError(t, err)
+		result1 = append(result1, v)
+		if v == 2 {
+			break
+		}
+	}
+	result2, err := collectTee(its[0])
+	assert.NilError(t, err)
+	result3, err := collectTee(its[2])
+	assert.ErrorIs(t, err, ErrTeeLag)
+
+	assert.DeepEqual(t, result1, []int{1, 2})
+	assert.DeepEqual(t, result2, []int{1, 2, 3, 4, 5, 6})
+	assert.Equal(t, len(result3), 0)
+}
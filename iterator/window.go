@@ -0,0 +1,115 @@
+package iterator
+
+import "slices"
+
+// PartialWindow controls what a window iterator does with a trailing window that has fewer than size elements
+type PartialWindow int
+
+const (
+	// DropPartial discards the trailing partial window
+	DropPartial PartialWindow = iota
+	// EmitPartial yields the trailing partial window with fewer than size elements
+	EmitPartial
+	// PadPartial fills the trailing partial window up to size with WindowOptions.Pad
+	PadPartial
+)
+
+type WindowOptions[T any] struct {
+	Partial PartialWindow
+	Pad     T
+	// Reuse makes the iterator yield views into a ring buffer shared between yields instead of allocating a new slice
+	// for every window. A yielded window is only valid until the next iteration.
+	Reuse bool
+}
+
+// Window returns an iterator that yields windows of size elements, with the start of each window step elements after
+// the start of the previous one. Windows overlap when step < size and skip elements when step > size. A trailing
+// partial window is dropped.
+func Window[T any](it func(func(T) bool), size, step int) func(func([]T) bool) {
+	return WindowWith(it, size, step, WindowOptions[T]{})
+}
+
+// Tumbling returns an iterator that yields consecutive non-overlapping windows of size elements, dropping a trailing
+// partial window
+func Tumbling[T any](it func(func(T) bool), size int) func(func([]T) bool) {
+	return Window(it, size, size)
+}
+
+// WindowWith is like Window, with the handling of trailing partial windows and buffer reuse controlled by opts
+func WindowWith[T any](it func(func(T) bool), size, step int, opts WindowOptions[T]) func(func([]T) bool) {
+	if size <= 0 || step <= 0 {
+		panic("iterator: window size and step must be positive")
+	}
+	return func(yield func([]T) bool) {
+		ring := newWindowRing[T](size)
+		skip := 0
+		fresh := 0
+		for t := range it {
+			if skip > 0 {
+				skip--
+				continue
+			}
+			ring.push(t)
+			fresh++
+			if ring.n < size {
+				continue
+			}
+
+			if !yield(ring.window(size, opts.Reuse)) {
+				return
+			}
+			fresh = 0
+			if step >= size {
+				ring.n = 0
+				skip = step - size
+			} else {
+				ring.n -= step
+			}
+		}
+
+		if fresh == 0 {
+			return
+		}
+		switch opts.Partial {
+		case EmitPartial:
+			yield(ring.window(ring.n, opts.Reuse))
+		case PadPartial:
+			for ring.n < size {
+				ring.push(opts.Pad)
+			}
+			yield(ring.window(size, opts.Reuse))
+		}
+	}
+}
+
+// windowRing is a ring buffer that stores every element twice, so that the most recent elements are always available
+// as a contiguous slice without copying
+type windowRing[T any] struct {
+	buf  []T
+	size int
+	pos  int
+	n    int
+}
+
+func newWindowRing[T any](size int) *windowRing[T] {
+	return &windowRing[T]{buf: make([]T, 2*size), size: size}
+}
+
+func (r *windowRing[T]) push(t T) {
+	r.buf[r.pos] = t
+	r.buf[r.pos+r.size] = t
+	r.pos = (r.pos + 1) % r.size
+	if r.n < r.size {
+		r.n++
+	}
+}
+
+// window returns the last n pushed elements
+func (r *windowRing[T]) window(n int, reuse bool) []T {
+	end := r.pos + r.size
+	w := r.buf[end-n : end : end]
+	if reuse {
+		return w
+	}
+	return slices.Clone(w)
+}
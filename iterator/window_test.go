@@ -0,0 +1,72 @@
+package iterator
+
+import (
+	"gotest.tools/v3/assert"
+	"slices"
+	"testing"
+)
+
+func TestWindow(t *testing.T) {
+	nums := []int{1, 2, 3, 4, 5, 6, 7}
+
+	tests := []struct {
+		size, step int
+		want       [][]int
+	}{
+		{3, 1, [][]int{{1, 2, 3}, {2, 3, 4}, {3, 4, 5}, {4, 5, 6}, {5, 6, 7}}},
+		{3, 2, [][]int{{1, 2, 3}, {3, 4, 5}, {5, 6, 7}}},
+		{2, 3, [][]int{{1, 2}, {4, 5}}},
+		{8, 1, nil},
+	}
+
+	for _, tc := range tests {
+		it := Window(slices.Values(nums), tc.size, tc.step)
+		assert.DeepEqual(t, slices.Collect(it), tc.want)
+	}
+}
+
+func TestTumbling(t *testing.T) {
+	it := Tumbling(slices.Values([]int{1, 2, 3, 4, 5, 6, 7}), 3)
+	assert.DeepEqual(t, slices.Collect(it), [][]int{{1, 2, 3}, {4, 5, 6}})
+}
+
+func TestWindowWith(t *testing.T) {
+	nums := []int{1, 2, 3, 4, 5, 6}
+
+	tests := []struct {
+		size, step int
+		opts       WindowOptions[int]
+		want       [][]int
+	}{
+		{4, 4, WindowOptions[int]{Partial: EmitPartial}, [][]int{{1, 2, 3, 4}, {5, 6}}},
+		{4, 4, WindowOptions[int]{Partial: PadPartial, Pad: -1}, [][]int{{1, 2, 3, 4}, {5, 6, -1, -1}}},
+		{3, 2, WindowOptions[int]{Partial: EmitPartial}, [][]int{{1, 2, 3}, {3, 4, 5}, {5, 6}}},
+		{3, 3, WindowOptions[int]{Partial: EmitPartial}, [][]int{{1, 2, 3}, {4, 5, 6}}},
+		{8, 1, WindowOptions[int]{Partial: PadPartial}, [][]int{{1, 2, 3, 4, 5, 6, 0, 0}}},
+	}
+
+	for _, tc := range tests {
+		it := WindowWith(slices.Values(nums), tc.size, tc.step, tc.opts)
+		assert.DeepEqual(t, slices.Collect(it), tc.want)
+	}
+}
+
+func TestWindowWith_Reuse(t *testing.T) {
+	it := WindowWith(slices.Values([]int{1, 2, 3, 4, 5}), 3, 1, WindowOptions[int]{Reuse: true})
+
+	var sums []int
+	for w := range it {
+		assert.Equal(t, cap(w), len(w))
+		sums = append(sums, Reduce(slices.Values(w), func(acc, n int) int { return acc + n }, 0))
+	}
+	assert.DeepEqual(t, sums, []int{6, 9, 12})
+}
+
+func BenchmarkWindowWith_Reuse(b *testing.B) {
+	it := WindowWith(TakeWhile(Generate(0, func(x int) int { return x + 1 }), func(x int) bool { return x < b.N }), 16, 1,
+		WindowOptions[int]{Reuse: true})
+	b.ReportAllocs()
+	b.ResetTimer()
+	for range it {
+	}
+}
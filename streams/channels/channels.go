@@ -1,6 +1,7 @@
 package channels
 
 import (
+	"cmp"
 	"context"
 	"go-exp/functions/reducers"
 	expiter "go-exp/iterator"
@@ -9,6 +10,24 @@ import (
 )
 
 func MergeOrdered[T constraints.Ordered](streams ...<-chan T) <-chan T {
+	return MergeOrderedFunc(cmp.Compare[T], streams...)
+}
+
+// MergeOrderedFunc merges channels that are sorted according to cmp into a single sorted channel
+func MergeOrderedFunc[T any](cmp func(a, b T) int, streams ...<-chan T) <-chan T {
+	return mergeOrdered(streams, func(its ...func(func(T) bool)) func(func(T) bool) {
+		return expiter.MergeOrderedFunc(cmp, its...)
+	})
+}
+
+// MergeOrderedStableFunc is like MergeOrderedFunc, but sends equal elements in the order of the channels they came from
+func MergeOrderedStableFunc[T any](cmp func(a, b T) int, streams ...<-chan T) <-chan T {
+	return mergeOrdered(streams, func(its ...func(func(T) bool)) func(func(T) bool) {
+		return expiter.MergeOrderedStableFunc(cmp, its...)
+	})
+}
+
+func mergeOrdered[T any](streams []<-chan T, merge func(...func(func(T) bool)) func(func(T) bool)) <-chan T {
 	its := make([]func(func(T) bool), len(streams))
 	for i, stream := range streams {
 		its[i] = Iterator(stream)
@@ -17,7 +36,7 @@ func MergeOrdered[T constraints.Ordered](streams ...<-chan T) <-chan T {
 	out := make(chan T, len(streams))
 	go func() {
 		defer close(out)
-		for t := range expiter.MergeOrdered(its...) {
+		for t := range merge(its...) {
 			out <- t
 		}
 	}()
@@ -138,6 +157,33 @@ func Filter[T any](buffer int, ch <-chan T, fn func(int, T) bool) <-chan T {
 	return out
 }
 
+// Distinct sends the first occurrence of each value received from ch
+func Distinct[T comparable](buffer int, ch <-chan T) <-chan T {
+	return fromIterator(buffer, expiter.Distinct(Iterator(ch)))
+}
+
+// DistinctBy sends the first value received from ch for each key returned by keyFn
+func DistinctBy[T any, K comparable](buffer int, ch <-chan T, keyFn func(T) K) <-chan T {
+	return fromIterator(buffer, expiter.DistinctBy(Iterator(ch), keyFn))
+}
+
+// DistinctBloom is like DistinctBy, but tracks seen keys in a Bloom filter of bounded size. See
+// iterator.DistinctBloom.
+func DistinctBloom[T any](buffer int, ch <-chan T, keyFn func(T) string, opts expiter.BloomOptions) <-chan T {
+	return fromIterator(buffer, expiter.DistinctBloom(Iterator(ch), keyFn, opts))
+}
+
+func fromIterator[T any](buffer int, it func(func(T) bool)) <-chan T {
+	out := make(chan T, buffer)
+	go func() {
+		defer close(out)
+		for t := range it {
+			out <- t
+		}
+	}()
+	return out
+}
+
 func DoWithIndex[T, V any](ch <-chan T, fn func(int, T)) <-chan struct{} {
 	done := make(chan struct{})
 	go func() {
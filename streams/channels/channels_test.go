@@ -2,9 +2,11 @@ package channels
 
 import (
 	"context"
+	"go-exp/functions"
 	"go-exp/functions/hof"
 	"go-exp/functions/operators"
 	"go-exp/functions/partials"
+	expiter "go-exp/iterator"
 	"go-exp/streams/collectors"
 	"gotest.tools/v3/assert"
 	"slices"
@@ -177,3 +179,31 @@ func assertAllSlicesEqual[T any](t *testing.T, expected []T, tss ...[]T) {
 		assert.DeepEqual(t, expected, ts)
 	}
 }
+
+func Test_MergeOrderedStableFunc(t *testing.T) {
+	type pair struct {
+		Key, Src int
+	}
+	a := FromSlice(0, []pair{{1, 0}, {2, 0}})
+	b := FromSlice(0, []pair{{0, 1}, {1, 1}, {2, 1}})
+	byKey := func(x, y pair) int { return x.Key - y.Key }
+
+	s := collectors.Slice(MergeOrderedStableFunc(byKey, a, b))
+	assert.DeepEqual(t, s, []pair{{0, 1}, {1, 0}, {1, 1}, {2, 0}, {2, 1}})
+}
+
+func Test_Distinct(t *testing.T) {
+	in := FromSlice(0, []int{1, 2, 1, 3, 2, 4})
+	assert.DeepEqual(t, collectors.Slice(Distinct(0, in)), []int{1, 2, 3, 4})
+}
+
+func Test_DistinctBy(t *testing.T) {
+	in := FromSlice(0, []string{"a", "B", "A", "b", "c"})
+	assert.DeepEqual(t, collectors.Slice(DistinctBy(0, in, strings.ToLower)), []string{"a", "B", "c"})
+}
+
+func Test_DistinctBloom(t *testing.T) {
+	in := FromSlice(0, []string{"x", "y", "x", "z", "y"})
+	opts := expiter.BloomOptions{ExpectedItems: 100, FalsePositiveRate: 0.001}
+	assert.DeepEqual(t, collectors.Slice(DistinctBloom(0, in, functions.Identity[string], opts)), []string{"x", "y", "z"})
+}
@@ -1,5 +1,11 @@
 package collectors
 
+import (
+	"encoding/csv"
+	"go-exp/iterator/ioseq"
+	"io"
+)
+
 func Slice[T any](ch <-chan T) []T {
 	s := make([]T, 0)
 	for x := range ch {
@@ -46,3 +52,48 @@ func ToMap[K comparable, V any](ch <-chan K, fn func(K) V, m map[K]V) map[K]V {
 	}
 	return m
 }
+
+// WriteLines writes each value received from ch to w, followed by a newline. See ioseq.WriteLines. If writing fails,
+// the rest of ch is drained so that senders are not blocked.
+func WriteLines[S ~string](w io.Writer, ch <-chan S, flushEvery int) (int, error) {
+	n, err := ioseq.WriteLines(w, values(ch), flushEvery)
+	if err != nil {
+		drain(ch)
+	}
+	return n, err
+}
+
+// WriteCSV writes each value received from ch to w as a CSV record. See ioseq.WriteCSV. Like WriteLines, it drains ch
+// if writing fails.
+func WriteCSV(w io.Writer, ch <-chan []string, flushEvery int, configure func(*csv.Writer)) (int, error) {
+	n, err := ioseq.WriteCSV(w, values(ch), flushEvery, configure)
+	if err != nil {
+		drain(ch)
+	}
+	return n, err
+}
+
+// WriteJSONL writes each value received from ch to w as a line of JSON. See ioseq.WriteJSONL. Like WriteLines, it
+// drains ch if writing fails.
+func WriteJSONL[T any](w io.Writer, ch <-chan T, flushEvery int) (int, error) {
+	n, err := ioseq.WriteJSONL(w, values(ch), flushEvery)
+	if err != nil {
+		drain(ch)
+	}
+	return n, err
+}
+
+func values[T any](ch <-chan T) func(func(T) bool) {
+	return func(yield func(T) bool) {
+		for x := range ch {
+			if !yield(x) {
+				return
+			}
+		}
+	}
+}
+
+func drain[T any](ch <-chan T) {
+	for range ch {
+	}
+}
@@ -0,0 +1,44 @@
+package collectors
+
+import (
+	"bytes"
+	"errors"
+	"gotest.tools/v3/assert"
+	"testing"
+)
+
+func sendAll[T any](s []T) <-chan T {
+	ch := make(chan T)
+	go func() {
+		defer close(ch)
+		for _, x := range s {
+			ch <- x
+		}
+	}()
+	return ch
+}
+
+func TestWriteLines(t *testing.T) {
+	var buf bytes.Buffer
+	n, err := WriteLines(&buf, sendAll([]string{"a", "b"}), 0)
+	assert.NilError(t, err)
+	assert.Equal(t, n, 2)
+	assert.Equal(t, buf.String(), "a\nb\n")
+}
+
+type failingWriter struct{}
+
+func (failingWriter) Write([]byte) (int, error) {
+	return 0, errors.New("closed")
+}
+
+func TestWriteJSONL_Error(t *testing.T) {
+	ch := sendAll([]int{1, 2, 3, 4})
+	n, err := WriteJSONL(failingWriter{}, ch, 1)
+	assert.ErrorContains(t, err, "closed")
+	assert.Equal(t, n, 0)
+
+	// the channel was drained
+	_, more := <-ch
+	assert.Assert(t, !more)
+}